@@ -1,21 +1,99 @@
 package bot
 
-import "net/http"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
 
 type Bot struct {
-	Token  string      `json:"token"`
-	Client http.Client `json:"-"`
+	Token       string       `json:"token"`
+	Client      http.Client  `json:"-"`
+	RetryPolicy *RetryPolicy `json:"-"`
+	RateLimiter *RateLimiter `json:"-"`
+	// Bot API server to talk to. Empty means APIURL.
+	BaseURL string `json:"-"`
+	// Use Telegram's test environment instead of the production one.
+	UseTestEnvironment bool `json:"-"`
+	// Longest time an API call may take. Zero means no limit.
+	RequestTimeout time.Duration `json:"-"`
+	// Sent as the User-Agent header of every request, unless empty.
+	UserAgent string `json:"-"`
+	// The bot's own user, as returned by GetMe. Filled in by CreateBot with ValidateToken, otherwise lazily by the Dispatcher.
+	Me *types.User `json:"-"`
 }
 
+// DefaultRequestTimeout is used by CreateBot when ClientOpts.RequestTimeout is zero.
+const DefaultRequestTimeout = time.Minute
+
 type ClientOpts struct {
+	// Used for every request to the Bot API.
 	Client http.Client
+	// Replaces the transport of Client, e.g. to go through a proxy.
+	Transport http.RoundTripper
+	// Longest time an API call may take, on top of the long polling timeout for getUpdates. Calls uploading
+	// files are not limited, as their duration depends on the file size. Defaults to DefaultRequestTimeout;
+	// a negative value disables the limit.
+	RequestTimeout time.Duration
+	// Sent as the User-Agent header of every request.
+	UserAgent string
+	// Call GetMe on creation, failing if the token is not accepted, and cache the result in Bot.Me.
+	ValidateToken bool
+	// Applied to every API call made through Request. Nil disables retrying.
+	RetryPolicy *RetryPolicy
+	// Throttle outgoing calls to stay within Telegram's limits. Nil disables throttling.
+	RateLimits *RateLimits
+	// URL of a self-hosted Bot API server, e.g. "http://localhost:8081". Defaults to APIURL.
+	BaseURL string
+	// Send requests to Telegram's test environment.
+	UseTestEnvironment bool
 }
 
 func CreateBot(token string, clientOpts *ClientOpts) (*Bot, error) {
 	b := &Bot{
-		Token:  token,
-		Client: http.Client{},
+		Token:          token,
+		Client:         http.Client{},
+		RequestTimeout: DefaultRequestTimeout,
+	}
+
+	if clientOpts != nil {
+		b.Client = clientOpts.Client
+		if clientOpts.Transport != nil {
+			b.Client.Transport = clientOpts.Transport
+		}
+		switch {
+		case clientOpts.RequestTimeout < 0:
+			b.RequestTimeout = 0
+		case clientOpts.RequestTimeout > 0:
+			b.RequestTimeout = clientOpts.RequestTimeout
+		}
+		b.UserAgent = clientOpts.UserAgent
+		b.RetryPolicy = clientOpts.RetryPolicy
+		b.BaseURL = strings.TrimRight(clientOpts.BaseURL, "/")
+		b.UseTestEnvironment = clientOpts.UseTestEnvironment
+		if clientOpts.RateLimits != nil {
+			b.RateLimiter = NewRateLimiter(*clientOpts.RateLimits)
+		}
+
+		if clientOpts.ValidateToken {
+			me, err := b.GetMeWithContext(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("failed to validate token: %w", err)
+			}
+			b.Me = me
+		}
 	}
 
 	return b, nil
 }
+
+// Id returns the bot's user id, which is the part of the token before the colon.
+func (b *Bot) Id() int64 {
+	id, _ := strconv.ParseInt(strings.SplitN(b.Token, ":", 2)[0], 10, 64)
+	return id
+}
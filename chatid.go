@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChatID identifies a chat either by its numeric id or, for channels and supergroups, by its @username.
+type ChatID struct {
+	Id       int64
+	Username string
+}
+
+// ID returns the ChatID of the chat with the given numeric id.
+func ID(id int64) ChatID {
+	return ChatID{Id: id}
+}
+
+// Username returns the ChatID of the public channel or supergroup with the given username. The leading @ is optional.
+func Username(username string) ChatID {
+	if username != "" && !strings.HasPrefix(username, "@") {
+		username = "@" + username
+	}
+	return ChatID{Username: username}
+}
+
+// IsZero reports whether the ChatID identifies no chat at all.
+func (c ChatID) IsZero() bool {
+	return c.Id == 0 && c.Username == ""
+}
+
+// String returns the chat_id as sent to the Bot API, or an empty string for the zero ChatID.
+func (c ChatID) String() string {
+	switch {
+	case c.Username != "":
+		return c.Username
+	case c.Id != 0:
+		return strconv.FormatInt(c.Id, 10)
+	default:
+		return ""
+	}
+}
+
+func (c ChatID) MarshalJSON() ([]byte, error) {
+	if c.Username != "" {
+		return json.Marshal(c.Username)
+	}
+	return json.Marshal(c.Id)
+}
+
+func (c *ChatID) UnmarshalJSON(data []byte) error {
+	var username string
+	if err := json.Unmarshal(data, &username); err == nil {
+		// Numeric ids are sometimes sent as strings, e.g. "-1001234567890".
+		if id, err := strconv.ParseInt(username, 10, 64); err == nil {
+			*c = ID(id)
+		} else {
+			*c = Username(username)
+		}
+		return nil
+	}
+
+	var id int64
+	if err := json.Unmarshal(data, &id); err != nil {
+		return fmt.Errorf("chat_id must be an integer or a string: %w", err)
+	}
+	*c = ID(id)
+	return nil
+}
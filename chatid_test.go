@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChatIDJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want ChatID
+		out  string
+	}{
+		{`-1001234567890`, ID(-1001234567890), `-1001234567890`},
+		{`42`, ID(42), `42`},
+		{`"-1001234567890"`, ID(-1001234567890), `-1001234567890`},
+		{`"42"`, ID(42), `42`},
+		{`"@channel"`, Username("@channel"), `"@channel"`},
+		{`"channel"`, Username("channel"), `"@channel"`},
+	}
+
+	for _, tt := range tests {
+		var got ChatID
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.json, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.json, got, tt.want)
+		}
+
+		out, err := json.Marshal(got)
+		if err != nil {
+			t.Errorf("Marshal(%+v): %v", got, err)
+			continue
+		}
+		if string(out) != tt.out {
+			t.Errorf("Marshal(%+v) = %s, want %s", got, out, tt.out)
+		}
+
+		var back ChatID
+		if err := json.Unmarshal(out, &back); err != nil || back != got {
+			t.Errorf("round trip of %+v gave %+v, %v", got, back, err)
+		}
+	}
+}
+
+func TestChatIDUnmarshalInvalid(t *testing.T) {
+	var c ChatID
+	if err := json.Unmarshal([]byte(`true`), &c); err == nil {
+		t.Errorf("Unmarshal(true) = %+v, want an error", c)
+	}
+}
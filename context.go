@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KeralaBots/GoTGramBot/filters"
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// Context is passed to every handler. It wraps the incoming update together with the
+// chat, user and message it concerns, and can be used as a context.Context for API calls.
+type Context struct {
+	context.Context
+
+	Update           *types.Update
+	EffectiveChat    *types.Chat
+	EffectiveUser    *types.User
+	EffectiveMessage *types.Message
+
+	// Set when the handler was matched by a Command filter.
+	Command *filters.ParsedCommand
+	// Regex capture groups of the handler's filter, Matches[0] being the whole match.
+	Matches []string
+
+	store *contextStore
+}
+
+type contextStore struct {
+	mu   sync.RWMutex
+	data map[string]interface{}
+	// Routes found by ConversationHandler.CheckUpdate, for its HandleUpdate.
+	conversations map[*ConversationHandler]*conversationRoute
+}
+
+// NewContext builds the Context for update, filling in the effective chat, user and message.
+func NewContext(ctx context.Context, update *types.Update) *Context {
+	c := &Context{
+		Context: ctx,
+		Update:  update,
+		store:   &contextStore{data: map[string]interface{}{}},
+	}
+
+	switch {
+	case update.Message != nil:
+		c.setMessage(update.Message)
+	case update.EditedMessage != nil:
+		c.setMessage(update.EditedMessage)
+	case update.ChannelPost != nil:
+		c.setMessage(update.ChannelPost)
+	case update.EditedChannelPost != nil:
+		c.setMessage(update.EditedChannelPost)
+	case update.BusinessMessage != nil:
+		c.setMessage(update.BusinessMessage)
+	case update.EditedBusinessMessage != nil:
+		c.setMessage(update.EditedBusinessMessage)
+	case update.CallbackQuery != nil:
+		c.EffectiveUser = update.CallbackQuery.From
+		if update.CallbackQuery.Message != nil {
+			m := types.Message(*update.CallbackQuery.Message)
+			c.EffectiveMessage = &m
+			c.EffectiveChat = m.Chat
+		}
+	case update.InlineQuery != nil:
+		c.EffectiveUser = update.InlineQuery.From
+	case update.ChosenInlineResult != nil:
+		c.EffectiveUser = update.ChosenInlineResult.From
+	case update.ShippingQuery != nil:
+		c.EffectiveUser = update.ShippingQuery.From
+	case update.PreCheckoutQuery != nil:
+		c.EffectiveUser = update.PreCheckoutQuery.From
+	case update.PollAnswer != nil:
+		c.EffectiveUser = update.PollAnswer.User
+		c.EffectiveChat = update.PollAnswer.VoterChat
+	case update.MyChatMember != nil:
+		c.EffectiveUser = update.MyChatMember.From
+		c.EffectiveChat = update.MyChatMember.Chat
+	case update.ChatMember != nil:
+		c.EffectiveUser = update.ChatMember.From
+		c.EffectiveChat = update.ChatMember.Chat
+	case update.ChatJoinRequest != nil:
+		c.EffectiveUser = update.ChatJoinRequest.From
+		c.EffectiveChat = update.ChatJoinRequest.Chat
+	case update.ChatBoost != nil:
+		c.EffectiveChat = update.ChatBoost.Chat
+	case update.RemovedChatBoost != nil:
+		c.EffectiveChat = update.RemovedChatBoost.Chat
+	case update.MessageReaction != nil:
+		c.EffectiveUser = update.MessageReaction.User
+		c.EffectiveChat = update.MessageReaction.Chat
+	case update.MessageReactionCount != nil:
+		c.EffectiveChat = update.MessageReactionCount.Chat
+	case update.BusinessConnection != nil:
+		c.EffectiveUser = update.BusinessConnection.User
+	case update.DeletedBusinessMessages != nil:
+		c.EffectiveChat = update.DeletedBusinessMessages.Chat
+	}
+
+	return c
+}
+
+func (c *Context) setMessage(m *types.Message) {
+	c.EffectiveMessage = m
+	c.EffectiveChat = m.Chat
+	c.EffectiveUser = m.From
+}
+
+// withMatch returns a copy of the context carrying the data extracted by a handler's filter.
+// The key/value store is shared between the copies.
+func (c *Context) withMatch(match *filters.Match) *Context {
+	nc := *c
+	if match == nil {
+		return &nc
+	}
+	nc.Command = match.Command
+	nc.Matches = match.Groups
+	return &nc
+}
+
+// Set stores a value for the rest of this update's handling, e.g. for passing data from middleware to handlers.
+func (c *Context) Set(key string, value interface{}) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.data[key] = value
+}
+
+// Get returns a value stored with Set.
+func (c *Context) Get(key string) (interface{}, bool) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+	v, ok := c.store.data[key]
+	return v, ok
+}
+
+// Delete removes a value stored with Set.
+func (c *Context) Delete(key string) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	delete(c.store.data, key)
+}
+
+// Args returns the arguments of the matched command, if any.
+func (c *Context) Args() []string {
+	if c.Command == nil {
+		return nil
+	}
+	return c.Command.Args
+}
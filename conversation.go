@@ -0,0 +1,261 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KeralaBots/GoTGramBot/filters"
+	"github.com/KeralaBots/GoTGramBot/storage"
+)
+
+// ConversationKey decides which updates belong to the same conversation.
+type ConversationKey int
+
+const (
+	// One conversation per user in every chat.
+	KeyPerUserInChat ConversationKey = iota
+	// One conversation per user, shared across chats.
+	KeyPerUser
+	// One conversation per chat, shared by all its members.
+	KeyPerChat
+)
+
+// ConversationStateChange is returned by conversation handlers to move to another state or end the conversation.
+type ConversationStateChange struct {
+	NextState string
+	End       bool
+}
+
+func (c *ConversationStateChange) Error() string {
+	if c.End {
+		return "conversation ended"
+	}
+	return fmt.Sprintf("conversation moved to state %s", c.NextState)
+}
+
+// NextConversationState moves the conversation to state once the handler returns.
+func NextConversationState(state string) error {
+	return &ConversationStateChange{NextState: state}
+}
+
+// EndConversation ends the conversation once the handler returns.
+func EndConversation() error {
+	return &ConversationStateChange{End: true}
+}
+
+type ConversationOpts struct {
+	// Distinguishes conversations sharing the same storage.
+	Name string
+	// Checked when no handler of the current state matches.
+	Fallbacks []Handler
+	// Defaults to KeyPerUserInChat.
+	Key ConversationKey
+	// Conversations without an update for this long are ended. Zero means never.
+	Timeout time.Duration
+	// Where conversation states are kept. Defaults to an in-memory storage.
+	Storage storage.Storage
+	// Let entry points restart a conversation which is already running.
+	AllowReEntry bool
+}
+
+// ConversationHandler routes updates through a multi-step flow. Entry points start the conversation,
+// after which only the handlers of the current state and the fallbacks are checked. Handlers change
+// the state by returning NextConversationState or EndConversation; returning nil keeps the current state.
+type ConversationHandler struct {
+	EntryPoints []Handler
+	States      map[string][]Handler
+	Fallbacks   []Handler
+
+	name         string
+	key          ConversationKey
+	timeout      time.Duration
+	storage      storage.Storage
+	allowReEntry bool
+}
+
+func NewConversationHandler(entryPoints []Handler, states map[string][]Handler, opts *ConversationOpts) *ConversationHandler {
+	c := &ConversationHandler{
+		EntryPoints: entryPoints,
+		States:      states,
+	}
+
+	if opts != nil {
+		c.Fallbacks = opts.Fallbacks
+		c.name = opts.Name
+		c.key = opts.Key
+		c.timeout = opts.Timeout
+		c.storage = opts.Storage
+		c.allowReEntry = opts.AllowReEntry
+	}
+	if c.storage == nil {
+		c.storage = storage.NewMemory()
+	}
+
+	return c
+}
+
+// conversationRoute is the handler an update was routed to, along with the conversation it belongs to.
+// It is kept on the Context so HandleUpdate does not read the storage and check the filters again.
+type conversationRoute struct {
+	key   storage.Key
+	state string
+	h     Handler
+	match *filters.Match
+	// Set when the state could not be loaded; HandleUpdate returns it.
+	err error
+}
+
+// CheckUpdate matches the updates one of the conversation's handlers wants. It also matches when the
+// conversation state cannot be loaded, so HandleUpdate reports the error instead of entry points firing again.
+func (c *ConversationHandler) CheckUpdate(b *Bot, ctx *Context) (*filters.Match, bool) {
+	r := c.route(b, ctx)
+	if r == nil {
+		return nil, false
+	}
+
+	ctx.store.mu.Lock()
+	defer ctx.store.mu.Unlock()
+	if ctx.store.conversations == nil {
+		ctx.store.conversations = make(map[*ConversationHandler]*conversationRoute)
+	}
+	ctx.store.conversations[c] = r
+
+	return r.match, true
+}
+
+func (c *ConversationHandler) HandleUpdate(b *Bot, ctx *Context) error {
+	ctx.store.mu.Lock()
+	r := ctx.store.conversations[c]
+	delete(ctx.store.conversations, c)
+	ctx.store.mu.Unlock()
+
+	if r == nil {
+		// Called without CheckUpdate.
+		if r = c.route(b, ctx); r == nil {
+			return ContinueGroups
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	key, state := r.key, r.state
+
+	err := r.h.HandleUpdate(b, ctx)
+
+	var change *ConversationStateChange
+	switch {
+	case errors.As(err, &change):
+		if change.End {
+			if err := c.storage.Delete(key); err != nil {
+				return fmt.Errorf("failed to end conversation %s: %w", key, err)
+			}
+			return nil
+		}
+		if _, ok := c.States[change.NextState]; !ok {
+			return fmt.Errorf("unknown conversation state %q", change.NextState)
+		}
+		if err := c.storage.Set(key, []byte(change.NextState), c.timeout); err != nil {
+			return fmt.Errorf("failed to save conversation %s: %w", key, err)
+		}
+		return nil
+	case err != nil:
+		return err
+	case state != "":
+		// Refresh the timeout of the current state.
+		if err := c.storage.Set(key, []byte(state), c.timeout); err != nil {
+			return fmt.Errorf("failed to save conversation %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ConversationHandler) SetUsername(username string) {
+	for _, h := range c.handlers() {
+		if s, ok := h.(filters.UsernameSetter); ok {
+			s.SetUsername(username)
+		}
+	}
+}
+
+// UpdateTypes returns the allowed_updates needed by the handlers of the conversation,
+// or nil if one of them does not tell.
+func (c *ConversationHandler) UpdateTypes() []string {
+	return handlerUpdateTypes(c.handlers())
+}
+
+func (c *ConversationHandler) handlers() []Handler {
+	all := append(append([]Handler{}, c.EntryPoints...), c.Fallbacks...)
+	for _, handlers := range c.States {
+		all = append(all, handlers...)
+	}
+	return all
+}
+
+// route finds the handler which should handle the update, given the current state of its conversation.
+// It returns nil if no handler wants the update.
+func (c *ConversationHandler) route(b *Bot, ctx *Context) *conversationRoute {
+	key, ok := c.conversationKey(b, ctx)
+	if !ok {
+		return nil
+	}
+
+	data, active, err := c.storage.Get(key)
+	if err != nil {
+		return &conversationRoute{key: key, err: fmt.Errorf("failed to load conversation %s: %w", key, err)}
+	}
+	state := string(data)
+
+	if !active || c.allowReEntry {
+		if h, match, ok := firstMatch(b, ctx, c.EntryPoints); ok {
+			return &conversationRoute{key: key, state: state, h: h, match: match}
+		}
+	}
+	if !active {
+		return nil
+	}
+
+	if h, match, ok := firstMatch(b, ctx, c.States[state]); ok {
+		return &conversationRoute{key: key, state: state, h: h, match: match}
+	}
+	if h, match, ok := firstMatch(b, ctx, c.Fallbacks); ok {
+		return &conversationRoute{key: key, state: state, h: h, match: match}
+	}
+
+	return nil
+}
+
+func (c *ConversationHandler) conversationKey(b *Bot, ctx *Context) (storage.Key, bool) {
+	key := storage.Key{BotId: b.Id(), Name: "conversation:" + c.name}
+
+	switch c.key {
+	case KeyPerUser:
+		if ctx.EffectiveUser == nil {
+			return key, false
+		}
+		key.UserId = ctx.EffectiveUser.Id
+	case KeyPerChat:
+		if ctx.EffectiveChat == nil {
+			return key, false
+		}
+		key.ChatId = ctx.EffectiveChat.Id
+	default:
+		if ctx.EffectiveUser == nil || ctx.EffectiveChat == nil {
+			return key, false
+		}
+		key.ChatId = ctx.EffectiveChat.Id
+		key.UserId = ctx.EffectiveUser.Id
+	}
+
+	return key, true
+}
+
+func firstMatch(b *Bot, ctx *Context, handlers []Handler) (Handler, *filters.Match, bool) {
+	for _, h := range handlers {
+		if match, ok := h.CheckUpdate(b, ctx); ok {
+			return h, match, true
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/KeralaBots/GoTGramBot/filters"
+	"github.com/KeralaBots/GoTGramBot/storage"
+)
+
+// flakyStorage counts the reads made from the storage it wraps, and fails them while err is set.
+type flakyStorage struct {
+	storage.Storage
+	gets int
+	err  error
+}
+
+func (s *flakyStorage) Get(key storage.Key) ([]byte, bool, error) {
+	s.gets++
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	return s.Storage.Get(key)
+}
+
+func TestConversationRoutesOnce(t *testing.T) {
+	store := &flakyStorage{Storage: storage.NewMemory()}
+	r := &recorder{}
+	d := (&Bot{}).NewDispatcher()
+	d.ErrorHandler = func(b *Bot, ctx *Context, err error) { t.Errorf("unexpected error: %v", err) }
+	d.AddHandler(NewConversationHandler(
+		[]Handler{NewMessageHandler(func(b *Bot, ctx *Context) error {
+			r.calls = append(r.calls, "start")
+			return NextConversationState("name")
+		}, filters.Regex("^start$"))},
+		map[string][]Handler{"name": {r.handler("name", filters.All, EndConversation())}},
+		&ConversationOpts{Storage: store},
+	))
+
+	for _, text := range []string{"start", "bob", "bob"} {
+		store.gets = 0
+		d.handleUpdate(NewContext(context.Background(), textUpdate(text)))
+		if store.gets != 1 {
+			t.Errorf("%q: storage read %d times, want once", text, store.gets)
+		}
+	}
+	if want := []string{"start", "name"}; !reflect.DeepEqual(r.calls, want) {
+		t.Errorf("handlers ran: %q, want %q", r.calls, want)
+	}
+}
+
+func TestConversationStorageError(t *testing.T) {
+	failed := errors.New("storage down")
+	store := &flakyStorage{Storage: storage.NewMemory(), err: failed}
+	r := &recorder{}
+	d := (&Bot{}).NewDispatcher()
+	var errs []error
+	d.ErrorHandler = func(b *Bot, ctx *Context, err error) { errs = append(errs, err) }
+	d.AddHandler(NewConversationHandler([]Handler{r.handler("start", filters.All, nil)}, nil, &ConversationOpts{Storage: store}))
+
+	d.handleUpdate(NewContext(context.Background(), textUpdate("start")))
+
+	if len(r.calls) != 0 {
+		t.Errorf("handlers ran: %q, want none while the state cannot be loaded", r.calls)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], failed) {
+		t.Errorf("errors = %v, want the storage error", errs)
+	}
+}
@@ -1,129 +1,267 @@
 package bot
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"sort"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/KeralaBots/GoTGramBot/filters"
-	"github.com/KeralaBots/GoTGramBot/types"
 )
 
 type Dispatcher struct {
-	Bot                   *Bot
-	IsRunning             bool
-	Offset                int64
-	MessageHandlers       []MessageHandlers
-	CallbackHandlers      []CallbackHandlers
-	EditedMessageHandlers []MessageHandlers
-}
+	Bot *Bot
+	// Set by Start and Stop. It is not updated when polling stops on its own; use PollerStatus from other goroutines.
+	IsRunning bool
+	// The next update to request. Updates below it have been acknowledged to Telegram.
+	Offset int64
+	// Saves Offset while polling and restores it on Start, so no update is lost or handled twice across restarts.
+	OffsetStore OffsetStore
+	// Discard the updates which arrived while the bot was not running, instead of handling them on start.
+	DropPendingUpdates bool
+	// Update types to receive. Nil derives them from the registered handlers, which requires every handler
+	// to have an UpdateTypes() []string method; otherwise all update types are requested. Handlers added
+	// after Start or StartWebhook are not taken into account.
+	AllowedUpdates []string
+	// Called with every error returned by a handler, including recovered panics. Defaults to logging them.
+	ErrorHandler ErrorHandler
+	// How long Stop waits for running handlers to finish. Defaults to 30 seconds.
+	ShutdownTimeout time.Duration
+	// Maximum number of updates handled at the same time. Zero means no limit.
+	MaxConcurrency int
+	// Maximum number of updates waiting for a worker when MaxConcurrency is set; receiving more updates
+	// blocks until some of them start. Defaults to 100 times MaxConcurrency.
+	MaxQueued int
+	// Handle updates of the same chat or user one at a time, while different chats still run in parallel.
+	Sequential SequentialMode
 
-type MessageDispatch func(b *Bot, m *types.Message) error
-type CallbackDispatch func(b *Bot, m *types.CallbackQuery) error
+	// ctx stops receiving updates, handlerCtx is given to handlers and outlives ctx until shutdown completes.
+	ctx           context.Context
+	cancel        context.CancelFunc
+	handlerCtx    context.Context
+	handlerCancel context.CancelFunc
+	server        *http.Server
+	serveDone     chan error
+	pollDone      chan struct{}
+	running       sync.WaitGroup
+	savedOffset   int64
 
-type MessageHandlers struct {
-	Function MessageDispatch
-	Filter   filters.FilterResponse
-}
+	handlersMu  sync.RWMutex
+	groups      map[int][]Handler
+	groupOrder  []int
+	middlewares []Middleware
 
-type CallbackHandlers struct {
-	Function CallbackDispatch
-	Filter   filters.FilterResponse
+	pollMu     sync.Mutex
+	pollStatus PollerStatus
+
+	queueMu sync.Mutex
+	queues  map[int64][]*Context
+	workers chan struct{}
+	backlog chan struct{}
 }
 
+const defaultShutdownTimeout = time.Second * 30
+
 func sigHandler(signal os.Signal) {
 	if signal == syscall.SIGTERM {
-		fmt.Print("SIGTERM signal recieved. Exiting....")
-		os.Exit(0)
+		fmt.Println("SIGTERM signal recieved. Exiting....")
 	} else if signal == syscall.SIGINT {
-		fmt.Print("SIGINT signal recieved. Exiting....")
-		os.Exit(0)
+		fmt.Println("SIGINT signal recieved. Exiting....")
 	} else if signal == syscall.SIGABRT {
-		fmt.Print("SIGABRT signal recieved. Exiting....")
-		os.Exit(0)
+		fmt.Println("SIGABRT signal recieved. Exiting....")
 	}
 }
 
+// Idle blocks until SIGINT, SIGTERM or SIGABRT is received, or the dispatcher stops on its own.
 func (d *Dispatcher) Idle() {
 	sigchnl := make(chan os.Signal, 1)
-	signal.Notify(sigchnl)
-	exitchnl := make(chan int)
+	signal.Notify(sigchnl, syscall.SIGINT, syscall.SIGTERM, syscall.SIGABRT)
+	defer signal.Stop(sigchnl)
 	fmt.Println("Idling.....\nPress CTRL + C to exit")
 
-	go func() {
-		for {
-			s := <-sigchnl
-			sigHandler(s)
-		}
-	}()
+	var done <-chan struct{}
+	if d.ctx != nil {
+		done = d.ctx.Done()
+	}
 
-	exitcode := <-exitchnl
-	os.Exit(exitcode)
+	select {
+	case s := <-sigchnl:
+		sigHandler(s)
+	case <-done:
+	}
 }
 
 func (d *Dispatcher) Start() {
-	d.IsRunning = true
+	d.StartWithContext(context.Background())
+}
+
+// StartWithContext starts polling for updates until ctx is cancelled or Stop is called.
+func (d *Dispatcher) StartWithContext(ctx context.Context) {
+	d.begin(ctx)
+	d.pollDone = make(chan struct{})
 	go d.handleWorkers()
 
 }
 
-func (d *Dispatcher) Stop() {
+func (d *Dispatcher) begin(ctx context.Context) {
+	d.ctx, d.cancel = context.WithCancel(ctx)
+	d.handlerCtx, d.handlerCancel = context.WithCancel(context.Background())
+	d.IsRunning = true
+}
+
+// Stop stops receiving updates, acknowledges the last processed update to Telegram and waits
+// up to ShutdownTimeout for running handlers to finish. If polling had stopped for good, it returns
+// PollerStatus().StopReason.
+func (d *Dispatcher) Stop() error {
+	if d.cancel == nil {
+		return nil
+	}
+
 	d.IsRunning = false
+
+	timeout := d.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	// Let in-flight webhook requests schedule their update before new ones are refused.
+	if d.server != nil {
+		if shutdownErr := d.server.Shutdown(ctx); shutdownErr != nil {
+			err = fmt.Errorf("failed to shut down webhook server: %w", shutdownErr)
+		}
+		d.server = nil
+	}
+	d.cancel()
+	if d.serveDone != nil {
+		if serveErr := <-d.serveDone; serveErr != nil && err == nil {
+			err = fmt.Errorf("webhook server failed: %w", serveErr)
+		}
+		d.serveDone = nil
+	}
+
+	if d.pollDone != nil {
+		<-d.pollDone
+		d.pollDone = nil
+
+		if reason := d.PollerStatus().StopReason; reason != nil {
+			// Already wraps the *TelegramError, e.g. a revoked token or another instance polling.
+			err = reason
+		} else if d.Offset > 0 {
+			if _, confirmErr := d.Bot.GetUpdatesWithContext(ctx, &GetUpdatesOpts{Offset: d.Offset, Limit: 1}); confirmErr != nil {
+				if err == nil {
+					err = fmt.Errorf("failed to confirm offset %d: %w", d.Offset, confirmErr)
+				}
+			} else {
+				d.saveOffset()
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if err == nil {
+			err = fmt.Errorf("timed out after %s waiting for handlers to finish", timeout)
+		}
+	}
+
+	d.handlerCancel()
+	d.cancel = nil
+	return err
 }
 
 func (b *Bot) NewDispatcher() *Dispatcher {
 	return &Dispatcher{
 		Bot:       b,
 		IsRunning: false,
-		Offset:    -1,
 	}
 }
 
-func (d *Dispatcher) AddMessageHandler(fn MessageDispatch, filter filters.FilterResponse) error {
-	if fn != nil {
-		res := MessageHandlers{
-			Function: fn,
-			Filter:   filter,
-		}
+// AddHandler adds h to the default group 0.
+func (d *Dispatcher) AddHandler(h Handler) {
+	d.AddHandlerToGroup(h, 0)
+}
 
-		d.MessageHandlers = append(d.MessageHandlers, res)
-		return nil
-	} else {
-		return fmt.Errorf("failed to add messagehandler")
+// AddHandlerToGroup adds h to group. Groups are processed in ascending order, and within a group
+// only the first handler whose CheckUpdate matches is run, unless it returns ContinueGroups.
+func (d *Dispatcher) AddHandlerToGroup(h Handler, group int) {
+	d.handlersMu.Lock()
+	defer d.handlersMu.Unlock()
+
+	if d.groups == nil {
+		d.groups = make(map[int][]Handler)
+	}
+	if _, ok := d.groups[group]; !ok {
+		i := sort.SearchInts(d.groupOrder, group)
+		d.groupOrder = append(d.groupOrder, 0)
+		copy(d.groupOrder[i+1:], d.groupOrder[i:])
+		d.groupOrder[i] = group
 	}
+	d.groups[group] = append(d.groups[group], h)
+
+	d.setUsername(h)
 }
 
-func (d *Dispatcher) AddEditedMessageHandler(fn MessageDispatch, filter filters.FilterResponse) error {
-	if fn != nil {
-		res := MessageHandlers{
-			Function: fn,
-			Filter:   filter,
-		}
+// handlerGroups returns a snapshot of the handlers, ordered by group.
+func (d *Dispatcher) handlerGroups() [][]Handler {
+	d.handlersMu.RLock()
+	defer d.handlersMu.RUnlock()
 
-		d.EditedMessageHandlers = append(d.EditedMessageHandlers, res)
-		return nil
-	} else {
-		return fmt.Errorf("failed to add edited_message_handler")
+	groups := make([][]Handler, 0, len(d.groupOrder))
+	for _, g := range d.groupOrder {
+		groups = append(groups, d.groups[g])
 	}
+	return groups
 }
 
-func (d *Dispatcher) AddCallbackHandler(fn CallbackDispatch, filter filters.FilterResponse) error {
-	if fn != nil {
-		res := CallbackHandlers{
-			Function: fn,
-			Filter:   filter,
+func (d *Dispatcher) setUsername(h Handler) {
+	if s, ok := h.(filters.UsernameSetter); ok && d.Bot.Me != nil {
+		s.SetUsername(d.Bot.Me.Username)
+	}
+}
+
+// loadUsername fetches the bot's own user, unless CreateBot already did, and passes its username
+// to the filters which need it, like commands.
+func (d *Dispatcher) loadUsername(ctx context.Context) error {
+	me := d.Bot.Me
+	if me == nil {
+		var err error
+		if me, err = d.Bot.GetMeWithContext(ctx); err != nil {
+			return fmt.Errorf("failed to get the bot's username: %w", err)
 		}
+	}
 
-		d.CallbackHandlers = append(d.CallbackHandlers, res)
-		return nil
-	} else {
-		return fmt.Errorf("failed to add callbackhandler")
+	d.handlersMu.Lock()
+	defer d.handlersMu.Unlock()
+
+	d.Bot.Me = me
+	for _, handlers := range d.groups {
+		for _, h := range handlers {
+			d.setUsername(h)
+		}
 	}
+
+	return nil
 }
 
-func (d *Dispatcher) Run() {
+// Run polls for updates until a termination signal is received or polling stops for good, then shuts down
+// gracefully. It returns the error of Stop, so a revoked token is told apart from a clean exit.
+func (d *Dispatcher) Run() error {
 	d.Start()
 	d.Idle()
-	d.Stop()
+	return d.Stop()
 }
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/KeralaBots/GoTGramBot/filters"
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// benchDispatcher registers 48 handlers, a mix of commands, regexes and combined media filters as a bigger
+// bot would, behind a middleware and spread over two groups.
+func benchDispatcher() *Dispatcher {
+	noop := func(b *Bot, ctx *Context) error { return nil }
+
+	d := (&Bot{Token: "1:token", Me: &types.User{Id: 1, IsBot: true, Username: "BenchBot"}}).NewDispatcher()
+	d.Use(func(next HandlerFunc) HandlerFunc {
+		return func(b *Bot, ctx *Context) error {
+			return next(b, ctx)
+		}
+	})
+	for i := 0; i < 16; i++ {
+		d.AddHandler(NewMessageHandler(noop, filters.Command(fmt.Sprintf("cmd%d", i), nil)))
+		d.AddHandler(NewMessageHandler(noop, filters.Regex(fmt.Sprintf(`^order (\d+) item%d$`, i))))
+		d.AddHandlerToGroup(NewMessageHandler(noop, filters.And(filters.Private, filters.Or(filters.Video, filters.Document), filters.Not(filters.Caption))), 1)
+	}
+	return d
+}
+
+func commandUpdate(command string) *types.Update {
+	return &types.Update{
+		UpdateId: 1,
+		Message: &types.Message{
+			MessageId: 1,
+			Chat:      &types.Chat{Id: 1, Type: "private"},
+			From:      &types.User{Id: 1, FirstName: "user"},
+			Text:      command + " first \"second arg\"",
+			Entities:  []types.MessageEntity{{Type: "bot_command", Offset: 0, Length: int64(len(command))}},
+		},
+	}
+}
+
+func benchmarkHandleUpdate(b *testing.B, update *types.Update) {
+	d := benchDispatcher()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.handleUpdate(NewContext(context.Background(), update))
+	}
+}
+
+func BenchmarkDispatchCommand(b *testing.B) {
+	benchmarkHandleUpdate(b, commandUpdate("/cmd15"))
+}
+
+func BenchmarkDispatchNoMatch(b *testing.B) {
+	update := commandUpdate("/unknown")
+	update.Message.Entities = nil
+	benchmarkHandleUpdate(b, update)
+}
+
+// BenchmarkProcessUpdate adds the cost of scheduling the update on a worker.
+func BenchmarkProcessUpdate(b *testing.B) {
+	d := benchDispatcher()
+	d.MaxConcurrency = 4
+	update := commandUpdate("/cmd15")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.ProcessUpdate(update)
+	}
+	d.running.Wait()
+}
@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+var _ types.Downloader = (*Bot)(nil)
+
+// GetFileURL returns the URL a file with the given file_path can be downloaded from the production Bot API server.
+func GetFileURL(token string, filePath string) string {
+	return fmt.Sprintf("%s/file/bot%s/%s", APIURL, token, filePath)
+}
+
+// GetFileURL returns the URL a file with the given file_path can be downloaded from the server b is configured for.
+func (b *Bot) GetFileURL(filePath string) string {
+	return fmt.Sprintf("%s/file/bot%s/%s%s", b.baseURL(), b.Token, b.environment(), filePath)
+}
+
+// DownloadFile fetches the content of the file with the given file_id. The caller must close the returned reader.
+func (b *Bot) DownloadFile(fileId string) (io.ReadCloser, error) {
+	return b.DownloadFileWithContext(context.Background(), fileId)
+}
+
+// DownloadFileWithContext is the same as DownloadFile, but uses the given context for the underlying requests.
+func (b *Bot) DownloadFileWithContext(ctx context.Context, fileId string) (io.ReadCloser, error) {
+	file, err := b.GetFileWithContext(ctx, fileId)
+	if err != nil {
+		return nil, err
+	}
+	return b.OpenFileWithContext(ctx, file)
+}
+
+// OpenFileWithContext fetches the content of a file returned by GetFile. A self-hosted Bot API server
+// running in local mode returns absolute paths, which are opened directly from disk.
+func (b *Bot) OpenFileWithContext(ctx context.Context, file *types.File) (io.ReadCloser, error) {
+	if file.FilePath == "" {
+		return nil, fmt.Errorf("file %s has no file_path, it may be too big to download", file.FileId)
+	}
+
+	if filepath.IsAbs(file.FilePath) {
+		f, err := os.Open(file.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open local file: %w", err)
+		}
+		return f, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.GetFileURL(file.FilePath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	if b.UserAgent != "" {
+		req.Header.Set("User-Agent", b.UserAgent)
+	}
+
+	res, err := b.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("download failed: %s", res.Status)
+	}
+
+	return res.Body, nil
+}
+
+// DownloadFileTo writes the content of the file with the given file_id to w, returning the number of bytes written.
+func (b *Bot) DownloadFileTo(fileId string, w io.Writer) (int64, error) {
+	return b.DownloadFileToWithContext(context.Background(), fileId, w)
+}
+
+// DownloadFileToWithContext is the same as DownloadFileTo, but uses the given context for the underlying requests.
+func (b *Bot) DownloadFileToWithContext(ctx context.Context, fileId string, w io.Writer) (int64, error) {
+	r, err := b.DownloadFileWithContext(ctx, fileId)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return n, fmt.Errorf("failed to read file content: %w", err)
+	}
+	return n, nil
+}
+
+// SaveFile downloads the file with the given file_id to path. Nothing is left at path if the download fails.
+func (b *Bot) SaveFile(fileId string, path string) error {
+	return b.SaveFileWithContext(context.Background(), fileId, path)
+}
+
+// SaveFileWithContext is the same as SaveFile, but uses the given context for the underlying requests.
+func (b *Bot) SaveFileWithContext(ctx context.Context, fileId string, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.part")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := b.DownloadFileToWithContext(ctx, fileId, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+var (
+	ErrFloodWait          = errors.New("flood wait")
+	ErrForbidden          = errors.New("forbidden")
+	ErrChatNotFound       = errors.New("chat not found")
+	ErrMessageNotModified = errors.New("message is not modified")
+	ErrChatMigrated       = errors.New("chat migrated")
+)
+
+var (
+	// EndGroups can be returned by a handler to stop processing the update in any later group.
+	EndGroups = errors.New("group iteration ended")
+	// ContinueGroups can be returned by a handler to keep looking for a matching handler in the
+	// current group, as though this handler did not match.
+	ContinueGroups = errors.New("group iteration continued")
+)
+
+// TelegramError is returned by Request when the Bot API answers with "ok": false.
+type TelegramError struct {
+	Method      string
+	Code        int
+	Description string
+	Parameters  *types.ResponseParameters
+}
+
+func (t *TelegramError) Error() string {
+	return fmt.Sprintf("telegram error [%d] in %s : %s", t.Code, t.Method, t.Description)
+}
+
+// Is reports whether the error matches one of the Err* sentinels of this package.
+func (t *TelegramError) Is(target error) bool {
+	switch target {
+	case ErrFloodWait:
+		return t.Code == http.StatusTooManyRequests
+	case ErrForbidden:
+		return t.Code == http.StatusForbidden
+	case ErrChatNotFound:
+		return t.Code == http.StatusBadRequest && strings.Contains(strings.ToLower(t.Description), "chat not found")
+	case ErrMessageNotModified:
+		return t.Code == http.StatusBadRequest && strings.Contains(strings.ToLower(t.Description), "message is not modified")
+	case ErrChatMigrated:
+		return t.MigrateToChatId() != 0
+	}
+	return false
+}
+
+// RetryAfter returns how long Telegram asked us to wait before repeating the request.
+func (t *TelegramError) RetryAfter() time.Duration {
+	if t.Parameters == nil {
+		return 0
+	}
+	return time.Duration(t.Parameters.RetryAfter) * time.Second
+}
+
+// MigrateToChatId returns the id of the supergroup a group has been migrated to, or 0.
+func (t *TelegramError) MigrateToChatId() int64 {
+	if t.Parameters == nil {
+		return 0
+	}
+	return t.Parameters.MigrateToChatId
+}
+
+func IsFloodWait(err error) bool {
+	return errors.Is(err, ErrFloodWait)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+func IsChatNotFound(err error) bool {
+	return errors.Is(err, ErrChatNotFound)
+}
+
+func IsMessageNotModified(err error) bool {
+	return errors.Is(err, ErrMessageNotModified)
+}
+
+func IsChatMigrated(err error) bool {
+	return errors.Is(err, ErrChatMigrated)
+}
+
+// ErrorHandler receives the errors returned by handlers, along with the context of the update which caused them.
+type ErrorHandler func(b *Bot, ctx *Context, err error)
+
+// PanicError is passed to the ErrorHandler when a handler panics.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", p.Value)
+}
+
+func defaultErrorHandler(b *Bot, ctx *Context, err error) {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		log.Printf("error while handling update %d: %v\n%s", ctx.Update.UpdateId, err, panicErr.Stack)
+		return
+	}
+	log.Printf("error while handling update %d: %v", ctx.Update.UpdateId, err)
+}
+
+// ReportToChat returns an ErrorHandler which logs errors and sends them to chatId, e.g. an admin group.
+func ReportToChat(chatId ChatID) ErrorHandler {
+	return func(b *Bot, ctx *Context, err error) {
+		defaultErrorHandler(b, ctx, err)
+
+		text := fmt.Sprintf("Error while handling update %d", ctx.Update.UpdateId)
+		if ctx.EffectiveChat != nil {
+			text += fmt.Sprintf(" in chat %d", ctx.EffectiveChat.Id)
+		}
+		if ctx.EffectiveUser != nil {
+			text += fmt.Sprintf(" from user %d", ctx.EffectiveUser.Id)
+		}
+		text += ":\n" + err.Error()
+
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) {
+			text += "\n\n" + string(panicErr.Stack)
+		}
+		if r := []rune(text); len(r) > 4096 {
+			text = string(r[:4096])
+		}
+
+		if _, sendErr := b.SendMessage(chatId, text, nil); sendErr != nil {
+			log.Printf("failed to report error to chat %s: %v", chatId, sendErr)
+		}
+	}
+}
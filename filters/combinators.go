@@ -0,0 +1,154 @@
+package filters
+
+import "github.com/KeralaBots/GoTGramBot/types"
+
+type andFilter []Filter
+
+// And matches only when every filter matches.
+func And(filters ...Filter) Filter {
+	return andFilter(filters)
+}
+
+func (f andFilter) CheckMessage(m *types.Message) bool {
+	for _, filter := range f {
+		if !filter.CheckMessage(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f andFilter) CheckCallback(m *types.CallbackQuery) bool {
+	for _, filter := range f {
+		if !filter.CheckCallback(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f andFilter) matchMessage(m *types.Message, match *Match) bool {
+	for _, filter := range f {
+		if !matchMessage(filter, m, match) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f andFilter) matchCallback(m *types.CallbackQuery, match *Match) bool {
+	for _, filter := range f {
+		if !matchCallback(filter, m, match) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f andFilter) SetUsername(username string) {
+	for _, filter := range f {
+		setUsername(filter, username)
+	}
+}
+
+type orFilter []Filter
+
+// Or matches when at least one filter matches.
+func Or(filters ...Filter) Filter {
+	return orFilter(filters)
+}
+
+func (f orFilter) CheckMessage(m *types.Message) bool {
+	for _, filter := range f {
+		if filter.CheckMessage(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f orFilter) CheckCallback(m *types.CallbackQuery) bool {
+	for _, filter := range f {
+		if filter.CheckCallback(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f orFilter) matchMessage(m *types.Message, match *Match) bool {
+	for _, filter := range f {
+		branch := *match
+		if matchMessage(filter, m, &branch) {
+			*match = branch
+			return true
+		}
+	}
+	return false
+}
+
+func (f orFilter) matchCallback(m *types.CallbackQuery, match *Match) bool {
+	for _, filter := range f {
+		branch := *match
+		if matchCallback(filter, m, &branch) {
+			*match = branch
+			return true
+		}
+	}
+	return false
+}
+
+func (f orFilter) SetUsername(username string) {
+	for _, filter := range f {
+		setUsername(filter, username)
+	}
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+// Not inverts filter.
+func Not(filter Filter) Filter {
+	return notFilter{filter: filter}
+}
+
+func (f notFilter) CheckMessage(m *types.Message) bool {
+	return !f.filter.CheckMessage(m)
+}
+
+func (f notFilter) CheckCallback(m *types.CallbackQuery) bool {
+	return !f.filter.CheckCallback(m)
+}
+
+func (f notFilter) SetUsername(username string) {
+	setUsername(f.filter, username)
+}
+
+// Func is a user defined message filter. It never matches callback queries.
+type Func func(m *types.Message) bool
+
+func (f Func) CheckMessage(m *types.Message) bool {
+	return f(m)
+}
+
+func (f Func) CheckCallback(m *types.CallbackQuery) bool {
+	return false
+}
+
+// CallbackFunc is a user defined callback query filter. It never matches messages.
+type CallbackFunc func(m *types.CallbackQuery) bool
+
+func (f CallbackFunc) CheckMessage(m *types.Message) bool {
+	return false
+}
+
+func (f CallbackFunc) CheckCallback(m *types.CallbackQuery) bool {
+	return f(m)
+}
+
+func setUsername(filter Filter, username string) {
+	if s, ok := filter.(UsernameSetter); ok {
+		s.SetUsername(username)
+	}
+}
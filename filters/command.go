@@ -0,0 +1,224 @@
+package filters
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// UsernameSetter is implemented by filters which need to know the username of the bot they run for.
+// The Dispatcher calls it with the username returned by GetMe.
+type UsernameSetter interface {
+	SetUsername(username string)
+}
+
+type CommandOpts struct {
+	// Defaults to '/'.
+	Prefixes []rune
+	// Other names the command responds to.
+	Aliases []string
+	// Match "/Start" and "/START" as well as "/start".
+	CaseInsensitive bool
+	// The bot's username without '@'. Commands addressed to another bot, e.g. /start@OtherBot, never match.
+	// Filled in by the Dispatcher if left empty.
+	Username string
+}
+
+// CommandFilter matches messages starting with one of its commands.
+type CommandFilter struct {
+	commands []string
+	opts     CommandOpts
+}
+
+// ParsedCommand is a command and its arguments as sent by the user.
+type ParsedCommand struct {
+	Prefix  rune
+	Command string
+	// Username the command was addressed to, without '@'. Empty if not addressed to a bot.
+	Username string
+	// Arguments split on whitespace. Quoted arguments keep their spaces.
+	Args []string
+	// Everything after the command, untouched.
+	RawArgs string
+}
+
+func Command(command string, prefixes []rune) *CommandFilter {
+	return CommandWithOpts(command, &CommandOpts{Prefixes: prefixes})
+}
+
+func CommandWithOpts(command string, opts *CommandOpts) *CommandFilter {
+	f := &CommandFilter{}
+	if opts != nil {
+		f.opts = *opts
+	}
+	if f.opts.Prefixes == nil {
+		f.opts.Prefixes = []rune{'/'}
+	}
+	f.commands = append([]string{command}, f.opts.Aliases...)
+
+	return f
+}
+
+func (f *CommandFilter) SetUsername(username string) {
+	if f.opts.Username == "" {
+		f.opts.Username = username
+	}
+}
+
+func (f *CommandFilter) CheckMessage(m *types.Message) bool {
+	return f.matchMessage(m, &Match{})
+}
+
+func (f *CommandFilter) matchMessage(m *types.Message, match *Match) bool {
+	cmd, ok := ParseCommand(m, f.opts.Prefixes)
+	if !ok {
+		return false
+	}
+	if cmd.Username != "" && !strings.EqualFold(cmd.Username, f.opts.Username) {
+		return false
+	}
+
+	for _, c := range f.commands {
+		if c == cmd.Command || (f.opts.CaseInsensitive && strings.EqualFold(c, cmd.Command)) {
+			match.Command = cmd
+			return true
+		}
+	}
+	return false
+}
+
+func (f *CommandFilter) CheckCallback(m *types.CallbackQuery) bool {
+	return false
+}
+
+func (f *CommandFilter) matchCallback(m *types.CallbackQuery, match *Match) bool {
+	return false
+}
+
+// ParseCommand extracts the command at the start of a message.
+// Commands using the '/' prefix must be marked by a bot_command entity at offset 0.
+func ParseCommand(m *types.Message, prefixes []rune) (*ParsedCommand, bool) {
+	if prefixes == nil {
+		prefixes = []rune{'/'}
+	}
+
+	text, entities := m.Text, m.Entities
+	if text == "" {
+		text, entities = m.Caption, m.CaptionEntities
+	}
+	if text == "" {
+		return nil, false
+	}
+
+	prefix, _ := utf8.DecodeRuneInString(text)
+	if !containsRune(prefixes, prefix) {
+		return nil, false
+	}
+
+	var token string
+	if prefix == '/' {
+		length := botCommandLength(entities)
+		if length == 0 {
+			return nil, false
+		}
+		token = utf16Prefix(text, length)
+	} else {
+		token = text
+		if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+			token = text[:i]
+		}
+	}
+
+	cmd := &ParsedCommand{
+		Prefix:  prefix,
+		Command: strings.TrimPrefix(token, string(prefix)),
+		RawArgs: strings.TrimSpace(text[len(token):]),
+	}
+	if i := strings.IndexRune(cmd.Command, '@'); i >= 0 {
+		cmd.Username = cmd.Command[i+1:]
+		cmd.Command = cmd.Command[:i]
+	}
+	if cmd.Command == "" {
+		return nil, false
+	}
+	cmd.Args = SplitArgs(cmd.RawArgs)
+
+	return cmd, true
+}
+
+// SplitArgs splits s on whitespace, keeping text inside single or double quotes together.
+// A backslash escapes the next character outside single quotes.
+func SplitArgs(s string) []string {
+	args := []string{}
+	var current strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
+
+func botCommandLength(entities []types.MessageEntity) int64 {
+	for _, e := range entities {
+		if e.Type == "bot_command" && e.Offset == 0 {
+			return e.Length
+		}
+	}
+	return 0
+}
+
+// utf16Prefix returns the part of s covering the first n UTF-16 code units, as used by entity offsets.
+func utf16Prefix(s string, n int64) string {
+	var units int64
+	for i, r := range s {
+		if units >= n {
+			return s[:i]
+		}
+		if r >= 0x10000 {
+			units += 2
+		} else {
+			units++
+		}
+	}
+	return s
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
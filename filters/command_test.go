@@ -0,0 +1,149 @@
+package filters
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// commandMessage returns a message with text, marking its first word as a bot_command like Telegram does for '/'.
+func commandMessage(text string) *types.Message {
+	word := text
+	if i := strings.IndexAny(text, " \n"); i >= 0 {
+		word = text[:i]
+	}
+	return &types.Message{
+		Text:     text,
+		Entities: []types.MessageEntity{{Type: "bot_command", Offset: 0, Length: int64(len(utf16.Encode([]rune(word))))}},
+	}
+}
+
+func TestCommandFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *CommandFilter
+		message *types.Message
+		want    bool
+	}{
+		{"command", Command("start", nil), commandMessage("/start"), true},
+		{"command with args", Command("start", nil), commandMessage("/start now"), true},
+		{"longer command", Command("start", nil), commandMessage("/restart"), false},
+		{"prefix of command", Command("start", nil), commandMessage("/sta"), false},
+		{"no bot_command entity", Command("start", nil), &types.Message{Text: "/start"}, false},
+		{"addressed to this bot", CommandWithOpts("start", &CommandOpts{Username: "MyBot"}), commandMessage("/start@MyBot"), true},
+		{"bot username case", CommandWithOpts("start", &CommandOpts{Username: "MyBot"}), commandMessage("/start@mybot"), true},
+		{"addressed to another bot", CommandWithOpts("start", &CommandOpts{Username: "MyBot"}), commandMessage("/start@OtherBot"), false},
+		{"alias", CommandWithOpts("start", &CommandOpts{Aliases: []string{"begin"}}), commandMessage("/begin"), true},
+		{"case sensitive", Command("start", nil), commandMessage("/START"), false},
+		{"case insensitive", CommandWithOpts("start", &CommandOpts{CaseInsensitive: true}), commandMessage("/START"), true},
+		{"caption", Command("start", nil), &types.Message{
+			Caption:         "/start now",
+			CaptionEntities: []types.MessageEntity{{Type: "bot_command", Offset: 0, Length: 6}},
+		}, true},
+		{"custom prefix", Command("start", []rune{'!'}), &types.Message{Text: "!start now"}, true},
+		{"prefix not allowed", Command("start", []rune{'!'}), commandMessage("/start"), false},
+		{"prefix only", Command("start", []rune{'!'}), &types.Message{Text: "!"}, false},
+		{"empty message", Command("start", nil), &types.Message{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.CheckMessage(tt.message); got != tt.want {
+				t.Errorf("CheckMessage(%q) = %v, want %v", tt.message.Text+tt.message.Caption, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetUsernameKeepsExplicitUsername(t *testing.T) {
+	f := CommandWithOpts("start", &CommandOpts{Username: "MyBot"})
+	f.SetUsername("OtherBot")
+	if !f.CheckMessage(commandMessage("/start@MyBot")) {
+		t.Error("SetUsername replaced the username given in CommandOpts")
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	cmd, ok := ParseCommand(commandMessage(`/start@MyBot first "second arg"`), nil)
+	if !ok {
+		t.Fatal("ParseCommand did not find the command")
+	}
+
+	want := &ParsedCommand{
+		Prefix:   '/',
+		Command:  "start",
+		Username: "MyBot",
+		Args:     []string{"first", "second arg"},
+		RawArgs:  `first "second arg"`,
+	}
+	if !reflect.DeepEqual(cmd, want) {
+		t.Errorf("ParseCommand = %+v, want %+v", cmd, want)
+	}
+}
+
+func TestParseCommandUTF16Offsets(t *testing.T) {
+	// The emoji takes two UTF-16 units but four bytes; the entity length is in UTF-16 units.
+	text := "/start😀@MyBot args"
+	m := &types.Message{
+		Text:     text,
+		Entities: []types.MessageEntity{{Type: "bot_command", Offset: 0, Length: 14}},
+	}
+
+	cmd, ok := ParseCommand(m, nil)
+	if !ok {
+		t.Fatal("ParseCommand did not find the command")
+	}
+	if cmd.Command != "start😀" || cmd.Username != "MyBot" || cmd.RawArgs != "args" {
+		t.Errorf("ParseCommand = %+v, want command start😀 for MyBot with args", cmd)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{"   ", []string{}},
+		{"a b  c", []string{"a", "b", "c"}},
+		{`"a b" c`, []string{"a b", "c"}},
+		{`'a "b"' c`, []string{`a "b"`, "c"}},
+		{`x"y z"`, []string{"xy z"}},
+		{`""`, []string{""}},
+		{`a\ b`, []string{"a b"}},
+		{`"a \" b"`, []string{`a " b`}},
+		{`'a\b'`, []string{`a\b`}},
+		{`"unterminated arg`, []string{"unterminated arg"}},
+		{`trailing\`, []string{"trailing"}},
+	}
+
+	for _, tt := range tests {
+		if got := SplitArgs(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUTF16Prefix(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int64
+		want string
+	}{
+		{"/start now", 6, "/start"},
+		{"/start", 10, "/start"},
+		{"/старт now", 6, "/старт"},
+		{"😀@bot x", 6, "😀@bot"},
+		{"a😀b", 3, "a😀"},
+		{"abc", 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := utf16Prefix(tt.s, tt.n); got != tt.want {
+			t.Errorf("utf16Prefix(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
@@ -1,160 +1,109 @@
 package filters
 
 import (
-	"encoding/json"
-	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/KeralaBots/GoTGramBot/types"
 )
 
-type FilterResponse struct {
-	Type     string
-	Data     string
-	Prefixes []rune
+// Filter decides whether a handler should run for a message or callback query.
+type Filter interface {
+	CheckMessage(m *types.Message) bool
+	CheckCallback(m *types.CallbackQuery) bool
 }
 
-var All FilterResponse = FilterResponse{Type: "all"}
-var Document FilterResponse = FilterResponse{Type: "document"}
-var Audio FilterResponse = FilterResponse{Type: "audio"}
-var Video FilterResponse = FilterResponse{Type: "video"}
-var VideoNote FilterResponse = FilterResponse{Type: "video_note"}
-var Voice FilterResponse = FilterResponse{Type: "voice"}
-var Sticker FilterResponse = FilterResponse{Type: "sticker"}
-var Animation FilterResponse = FilterResponse{Type: "animation"}
-var ViaBot FilterResponse = FilterResponse{Type: "via_bot"}
-var Poll FilterResponse = FilterResponse{Type: "poll"}
-var Caption FilterResponse = FilterResponse{Type: "caption"}
-var Dice FilterResponse = FilterResponse{Type: "dice"}
-var Game FilterResponse = FilterResponse{Type: "game"}
-var Venue FilterResponse = FilterResponse{Type: "venue"}
-var Location FilterResponse = FilterResponse{Type: "location"}
-var NewChatTitle FilterResponse = FilterResponse{Type: "new_chat_title"}
-var NewChatPhoto FilterResponse = FilterResponse{Type: "new_chat_photo"}
-var Invoice FilterResponse = FilterResponse{Type: "invoice"}
-var HasProtectedContent FilterResponse = FilterResponse{Type: "has_protected_content"}
-var VideoChatScheduled FilterResponse = FilterResponse{Type: "video_chat_scheduled"}
-var VideoChatStarted FilterResponse = FilterResponse{Type: "video_chat_started"}
-var VideoChatEnded FilterResponse = FilterResponse{Type: "video_chat_ended"}
-var VideoChatParticipantsInvited FilterResponse = FilterResponse{Type: "video_chat_participants_invited"}
-var SuccessfulPayment FilterResponse = FilterResponse{Type: "successful_payment"}
-var Private FilterResponse = FilterResponse{Type: "chat", Data: "private"}
-var Group FilterResponse = FilterResponse{Type: "chat", Data: "group"}
-var SuperGroup FilterResponse = FilterResponse{Type: "chat", Data: "supergroup"}
-var Channel FilterResponse = FilterResponse{Type: "chat", Data: "channel"}
-
-func Command(command string, prefixes []rune) FilterResponse {
-	if prefixes == nil {
-		prefixes = []rune{'/'}
-	}
-	data := FilterResponse{
-		Type:     "command",
-		Data:     command,
-		Prefixes: prefixes,
-	}
+// Deprecated: FilterResponse is kept for compatibility, use Filter instead.
+type FilterResponse = Filter
+
+var All Filter = allFilter{}
+var Document Filter = Func(func(m *types.Message) bool { return m.Document != nil })
+var Audio Filter = Func(func(m *types.Message) bool { return m.Audio != nil })
+var Video Filter = Func(func(m *types.Message) bool { return m.Video != nil })
+var VideoNote Filter = Func(func(m *types.Message) bool { return m.VideoNote != nil })
+var Voice Filter = Func(func(m *types.Message) bool { return m.Voice != nil })
+var Sticker Filter = Func(func(m *types.Message) bool { return m.Sticker != nil })
+var Animation Filter = Func(func(m *types.Message) bool { return m.Animation != nil })
+var ViaBot Filter = Func(func(m *types.Message) bool { return m.ViaBot != nil })
+var Poll Filter = Func(func(m *types.Message) bool { return m.Poll != nil })
+var Caption Filter = Func(func(m *types.Message) bool { return m.Caption != "" })
+var Dice Filter = Func(func(m *types.Message) bool { return m.Dice != nil })
+var Game Filter = Func(func(m *types.Message) bool { return m.Game != nil })
+var Venue Filter = Func(func(m *types.Message) bool { return m.Venue != nil })
+var Location Filter = Func(func(m *types.Message) bool { return m.Location != nil })
+var NewChatTitle Filter = Func(func(m *types.Message) bool { return m.NewChatTitle != "" })
+var NewChatPhoto Filter = Func(func(m *types.Message) bool { return len(m.NewChatPhoto) > 0 })
+var Invoice Filter = Func(func(m *types.Message) bool { return m.Invoice != nil })
+var HasProtectedContent Filter = Func(func(m *types.Message) bool { return m.HasProtectedContent })
+var VideoChatScheduled Filter = Func(func(m *types.Message) bool { return m.VideoChatScheduled != nil })
+var VideoChatStarted Filter = Func(func(m *types.Message) bool { return m.VideoChatStarted != nil })
+var VideoChatEnded Filter = Func(func(m *types.Message) bool { return m.VideoChatEnded != nil })
+var VideoChatParticipantsInvited Filter = Func(func(m *types.Message) bool { return m.VideoChatParticipantsInvited != nil })
+var SuccessfulPayment Filter = Func(func(m *types.Message) bool { return m.SuccessfulPayment != nil })
+var Private Filter = chatFilter("private")
+var Group Filter = chatFilter("group")
+var SuperGroup Filter = chatFilter("supergroup")
+var Channel Filter = chatFilter("channel")
+
+type allFilter struct{}
+
+func (allFilter) CheckMessage(m *types.Message) bool {
+	return true
+}
 
-	return data
+func (allFilter) CheckCallback(m *types.CallbackQuery) bool {
+	return true
 }
 
-func Regex(regex string) FilterResponse {
-	data := FilterResponse{
-		Type: "regex",
-		Data: regex,
-	}
+type chatFilter string
 
-	return data
+func (f chatFilter) CheckMessage(m *types.Message) bool {
+	return m.Chat != nil && m.Chat.Type == string(f)
 }
 
-func CallbackData(data string) FilterResponse {
-	return FilterResponse{
-		Type: "callback_data",
-		Data: data,
-	}
+func (f chatFilter) CheckCallback(m *types.CallbackQuery) bool {
+	return m.Message != nil && m.Message.Chat != nil && m.Message.Chat.Type == string(f)
 }
 
-func (f *FilterResponse) CheckMessage(m *types.Message) bool {
-	rawUpdate, err := json.Marshal(m)
-	if err != nil {
-		return false
-	}
-
-	u := make(map[string]interface{})
-	err = json.Unmarshal(rawUpdate, &u)
-	if err != nil {
-		return false
-	}
+type regexFilter struct {
+	re           *regexp.Regexp
+	callbackOnly bool
+}
 
-	res := false
-	if u["text"] != nil {
-		text := fmt.Sprintf("%v", u["text"])
-
-		if f.Type == "command" {
-			for _, p := range f.Prefixes {
-				if strings.HasPrefix(text, string(p)) {
-					re, _ := regexp.MatchString(f.Data, text)
-					if re {
-						res = true
-					}
-				}
-			}
-		}
-
-		if f.Type == "regex" {
-			re, _ := regexp.MatchString(f.Data, text)
-			if re {
-				res = true
-			}
-		}
-	} else if u[f.Type] != nil {
-		res = true
-	} else {
-		res = false
-	}
+// Regex matches the text of messages and the data of callback queries. The pattern is compiled once and panics if invalid.
+func Regex(regex string) Filter {
+	return regexFilter{re: regexp.MustCompile(regex)}
+}
 
-	if f.Type == "all" {
-		res = true
-	}
+// CallbackData matches callback queries whose data matches the regex data.
+func CallbackData(data string) Filter {
+	return regexFilter{re: regexp.MustCompile(data), callbackOnly: true}
+}
 
-	if f.Type == "chat" {
-		if m.Chat.Type == f.Data {
-			res = true
-		} else {
-			res = false
-		}
-	}
+func (f regexFilter) CheckMessage(m *types.Message) bool {
+	return !f.callbackOnly && m.Text != "" && f.re.MatchString(m.Text)
+}
 
-	return res
+func (f regexFilter) CheckCallback(m *types.CallbackQuery) bool {
+	return f.re.MatchString(m.Data)
 }
 
-func (f *FilterResponse) CheckCallback(m *types.CallbackQuery) bool {
-	res := false
-
-	if f.Type == "callback_data" {
-		re, _ := regexp.MatchString(f.Data, m.Data)
-		if re {
-			res = true
-		}
-	} else if f.Type == "regex" {
-		re, _ := regexp.MatchString(f.Data, m.Data)
-		if re {
-			res = true
-		}
-	} else {
-		res = false
+func (f regexFilter) matchMessage(m *types.Message, match *Match) bool {
+	if f.callbackOnly || m.Text == "" {
+		return false
 	}
-
-	if f.Type == "all" {
-		res = true
+	groups := f.re.FindStringSubmatch(m.Text)
+	if groups == nil {
+		return false
 	}
+	match.Groups = groups
+	return true
+}
 
-	if f.Type == "chat" {
-		if m.Message.Chat.Type == f.Data {
-			res = true
-		} else {
-			res = true
-		}
+func (f regexFilter) matchCallback(m *types.CallbackQuery, match *Match) bool {
+	groups := f.re.FindStringSubmatch(m.Data)
+	if groups == nil {
+		return false
 	}
-
-	return res
+	match.Groups = groups
+	return true
 }
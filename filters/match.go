@@ -0,0 +1,49 @@
+package filters
+
+import "github.com/KeralaBots/GoTGramBot/types"
+
+// Match holds the data a filter extracted from the update it matched.
+type Match struct {
+	// Set by Command filters.
+	Command *ParsedCommand
+	// Capture groups of Regex and CallbackData filters, Groups[0] being the whole match.
+	Groups []string
+}
+
+// matcher is implemented by filters which extract data while matching.
+type matcher interface {
+	matchMessage(m *types.Message, match *Match) bool
+	matchCallback(m *types.CallbackQuery, match *Match) bool
+}
+
+// MatchMessage checks m against f and returns the data extracted on the way. A nil filter matches everything.
+func MatchMessage(f Filter, m *types.Message) (*Match, bool) {
+	match := &Match{}
+	if f == nil {
+		return match, true
+	}
+	return match, matchMessage(f, m, match)
+}
+
+// MatchCallback checks m against f and returns the data extracted on the way. A nil filter matches everything.
+func MatchCallback(f Filter, m *types.CallbackQuery) (*Match, bool) {
+	match := &Match{}
+	if f == nil {
+		return match, true
+	}
+	return match, matchCallback(f, m, match)
+}
+
+func matchMessage(f Filter, m *types.Message, match *Match) bool {
+	if mf, ok := f.(matcher); ok {
+		return mf.matchMessage(m, match)
+	}
+	return f.CheckMessage(m)
+}
+
+func matchCallback(f Filter, m *types.CallbackQuery, match *Match) bool {
+	if mf, ok := f.(matcher); ok {
+		return mf.matchCallback(m, match)
+	}
+	return f.CheckCallback(m)
+}
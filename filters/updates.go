@@ -0,0 +1,71 @@
+package filters
+
+import (
+	"regexp"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// Filters for update kinds other than messages and callback queries. A nil filter matches every update.
+
+type InlineQueryFilter func(u *types.InlineQuery) bool
+type ChosenInlineResultFilter func(u *types.ChosenInlineResult) bool
+type ShippingQueryFilter func(u *types.ShippingQuery) bool
+type PreCheckoutQueryFilter func(u *types.PreCheckoutQuery) bool
+type PollFilter func(u *types.Poll) bool
+type PollAnswerFilter func(u *types.PollAnswer) bool
+type ChatMemberUpdatedFilter func(u *types.ChatMemberUpdated) bool
+type ChatJoinRequestFilter func(u *types.ChatJoinRequest) bool
+type ChatBoostUpdatedFilter func(u *types.ChatBoostUpdated) bool
+type ChatBoostRemovedFilter func(u *types.ChatBoostRemoved) bool
+type MessageReactionUpdatedFilter func(u *types.MessageReactionUpdated) bool
+type MessageReactionCountUpdatedFilter func(u *types.MessageReactionCountUpdated) bool
+type BusinessConnectionFilter func(u *types.BusinessConnection) bool
+type BusinessMessagesDeletedFilter func(u *types.BusinessMessagesDeleted) bool
+
+// InlineQueryRegex matches inline queries whose query text matches regex.
+func InlineQueryRegex(regex string) InlineQueryFilter {
+	re := regexp.MustCompile(regex)
+	return func(u *types.InlineQuery) bool {
+		return re.MatchString(u.Query)
+	}
+}
+
+// ChosenInlineResultRegex matches chosen inline results whose result id matches regex.
+func ChosenInlineResultRegex(regex string) ChosenInlineResultFilter {
+	re := regexp.MustCompile(regex)
+	return func(u *types.ChosenInlineResult) bool {
+		return re.MatchString(u.ResultId)
+	}
+}
+
+// ShippingPayload matches shipping queries whose invoice payload matches regex.
+func ShippingPayload(regex string) ShippingQueryFilter {
+	re := regexp.MustCompile(regex)
+	return func(u *types.ShippingQuery) bool {
+		return re.MatchString(u.InvoicePayload)
+	}
+}
+
+// PreCheckoutPayload matches pre-checkout queries whose invoice payload matches regex.
+func PreCheckoutPayload(regex string) PreCheckoutQueryFilter {
+	re := regexp.MustCompile(regex)
+	return func(u *types.PreCheckoutQuery) bool {
+		return re.MatchString(u.InvoicePayload)
+	}
+}
+
+// ChatMemberStatus matches chat member updates where the new status is one of statuses.
+func ChatMemberStatus(statuses ...string) ChatMemberUpdatedFilter {
+	return func(u *types.ChatMemberUpdated) bool {
+		if u.NewChatMember == nil {
+			return false
+		}
+		for _, s := range statuses {
+			if u.NewChatMember.Status == s {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,430 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/KeralaBots/GoTGramBot/filters"
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// Handler is anything the Dispatcher can route updates to.
+type Handler interface {
+	// CheckUpdate reports whether the handler wants the update, along with the data its filter extracted.
+	CheckUpdate(b *Bot, ctx *Context) (*filters.Match, bool)
+	HandleUpdate(b *Bot, ctx *Context) error
+}
+
+// HandlerFunc is called for every update matching a handler's filter.
+type HandlerFunc func(b *Bot, ctx *Context) error
+
+type MessageHandlers struct {
+	Function HandlerFunc
+	Filter   filters.Filter
+
+	message     func(u *types.Update) *types.Message
+	updateTypes []string
+}
+
+func (h *MessageHandlers) CheckUpdate(b *Bot, ctx *Context) (*filters.Match, bool) {
+	if h.message == nil {
+		// Built as a struct literal instead of with a New*Handler constructor.
+		return nil, false
+	}
+	m := h.message(ctx.Update)
+	if m == nil {
+		return nil, false
+	}
+	return filters.MatchMessage(h.Filter, m)
+}
+
+func (h *MessageHandlers) HandleUpdate(b *Bot, ctx *Context) error {
+	return h.Function(b, ctx)
+}
+
+// UpdateTypes returns the allowed_updates this handler needs.
+func (h *MessageHandlers) UpdateTypes() []string {
+	return h.updateTypes
+}
+
+func (h *MessageHandlers) SetUsername(username string) {
+	if s, ok := h.Filter.(filters.UsernameSetter); ok {
+		s.SetUsername(username)
+	}
+}
+
+type CallbackHandlers struct {
+	Function HandlerFunc
+	Filter   filters.Filter
+}
+
+func (h *CallbackHandlers) CheckUpdate(b *Bot, ctx *Context) (*filters.Match, bool) {
+	if ctx.Update.CallbackQuery == nil {
+		return nil, false
+	}
+	return filters.MatchCallback(h.Filter, ctx.Update.CallbackQuery)
+}
+
+func (h *CallbackHandlers) HandleUpdate(b *Bot, ctx *Context) error {
+	return h.Function(b, ctx)
+}
+
+// UpdateTypes returns the allowed_updates this handler needs.
+func (h *CallbackHandlers) UpdateTypes() []string {
+	return []string{"callback_query"}
+}
+
+func (h *CallbackHandlers) SetUsername(username string) {
+	if s, ok := h.Filter.(filters.UsernameSetter); ok {
+		s.SetUsername(username)
+	}
+}
+
+// UpdateHandler handles a kind of update other than messages and callback queries, e.g. polls or
+// inline queries. T is the type of the update field it handles, like types.Poll.
+type UpdateHandler[T any] struct {
+	Function HandlerFunc
+	// A nil filter matches every update.
+	Filter func(u *T) bool
+
+	field       func(u *types.Update) *T
+	updateTypes []string
+}
+
+func newUpdateHandler[T any](field func(u *types.Update) *T, updateType string, fn HandlerFunc, filter func(u *T) bool) *UpdateHandler[T] {
+	return &UpdateHandler[T]{
+		Function:    fn,
+		Filter:      filter,
+		field:       field,
+		updateTypes: []string{updateType},
+	}
+}
+
+func (h *UpdateHandler[T]) CheckUpdate(b *Bot, ctx *Context) (*filters.Match, bool) {
+	if h.field == nil {
+		// Built as a struct literal instead of with a New*Handler constructor.
+		return nil, false
+	}
+	u := h.field(ctx.Update)
+	if u == nil || (h.Filter != nil && !h.Filter(u)) {
+		return nil, false
+	}
+	return &filters.Match{}, true
+}
+
+func (h *UpdateHandler[T]) HandleUpdate(b *Bot, ctx *Context) error {
+	return h.Function(b, ctx)
+}
+
+// UpdateTypes returns the allowed_updates this handler needs.
+func (h *UpdateHandler[T]) UpdateTypes() []string {
+	return h.updateTypes
+}
+
+func NewMessageHandler(fn HandlerFunc, filter filters.Filter) *MessageHandlers {
+	return &MessageHandlers{
+		Function: fn,
+		Filter:   filter,
+		message: func(u *types.Update) *types.Message {
+			if u.Message != nil {
+				return u.Message
+			}
+			if u.ChannelPost != nil {
+				return u.ChannelPost
+			}
+			return nil
+		},
+		updateTypes: []string{"message", "channel_post"},
+	}
+}
+
+func NewEditedMessageHandler(fn HandlerFunc, filter filters.Filter) *MessageHandlers {
+	return &MessageHandlers{
+		Function: fn,
+		Filter:   filter,
+		message: func(u *types.Update) *types.Message {
+			if u.EditedMessage != nil {
+				return u.EditedMessage
+			}
+			if u.EditedChannelPost != nil {
+				return u.EditedChannelPost
+			}
+			return nil
+		},
+		updateTypes: []string{"edited_message", "edited_channel_post"},
+	}
+}
+
+func NewBusinessMessageHandler(fn HandlerFunc, filter filters.Filter) *MessageHandlers {
+	return &MessageHandlers{
+		Function: fn,
+		Filter:   filter,
+		message: func(u *types.Update) *types.Message {
+			if u.BusinessMessage != nil {
+				return u.BusinessMessage
+			}
+			return nil
+		},
+		updateTypes: []string{"business_message"},
+	}
+}
+
+func NewEditedBusinessMessageHandler(fn HandlerFunc, filter filters.Filter) *MessageHandlers {
+	return &MessageHandlers{
+		Function: fn,
+		Filter:   filter,
+		message: func(u *types.Update) *types.Message {
+			if u.EditedBusinessMessage != nil {
+				return u.EditedBusinessMessage
+			}
+			return nil
+		},
+		updateTypes: []string{"edited_business_message"},
+	}
+}
+
+func NewCallbackHandler(fn HandlerFunc, filter filters.Filter) *CallbackHandlers {
+	return &CallbackHandlers{
+		Function: fn,
+		Filter:   filter,
+	}
+}
+
+func NewInlineQueryHandler(fn HandlerFunc, filter filters.InlineQueryFilter) *UpdateHandler[types.InlineQuery] {
+	return newUpdateHandler(func(u *types.Update) *types.InlineQuery { return u.InlineQuery }, "inline_query", fn, filter)
+}
+
+func NewChosenInlineResultHandler(fn HandlerFunc, filter filters.ChosenInlineResultFilter) *UpdateHandler[types.ChosenInlineResult] {
+	return newUpdateHandler(func(u *types.Update) *types.ChosenInlineResult { return u.ChosenInlineResult }, "chosen_inline_result", fn, filter)
+}
+
+func NewShippingQueryHandler(fn HandlerFunc, filter filters.ShippingQueryFilter) *UpdateHandler[types.ShippingQuery] {
+	return newUpdateHandler(func(u *types.Update) *types.ShippingQuery { return u.ShippingQuery }, "shipping_query", fn, filter)
+}
+
+func NewPreCheckoutQueryHandler(fn HandlerFunc, filter filters.PreCheckoutQueryFilter) *UpdateHandler[types.PreCheckoutQuery] {
+	return newUpdateHandler(func(u *types.Update) *types.PreCheckoutQuery { return u.PreCheckoutQuery }, "pre_checkout_query", fn, filter)
+}
+
+func NewPollHandler(fn HandlerFunc, filter filters.PollFilter) *UpdateHandler[types.Poll] {
+	return newUpdateHandler(func(u *types.Update) *types.Poll { return u.Poll }, "poll", fn, filter)
+}
+
+func NewPollAnswerHandler(fn HandlerFunc, filter filters.PollAnswerFilter) *UpdateHandler[types.PollAnswer] {
+	return newUpdateHandler(func(u *types.Update) *types.PollAnswer { return u.PollAnswer }, "poll_answer", fn, filter)
+}
+
+func NewMyChatMemberHandler(fn HandlerFunc, filter filters.ChatMemberUpdatedFilter) *UpdateHandler[types.ChatMemberUpdated] {
+	return newUpdateHandler(func(u *types.Update) *types.ChatMemberUpdated { return u.MyChatMember }, "my_chat_member", fn, filter)
+}
+
+func NewChatMemberHandler(fn HandlerFunc, filter filters.ChatMemberUpdatedFilter) *UpdateHandler[types.ChatMemberUpdated] {
+	return newUpdateHandler(func(u *types.Update) *types.ChatMemberUpdated { return u.ChatMember }, "chat_member", fn, filter)
+}
+
+func NewChatJoinRequestHandler(fn HandlerFunc, filter filters.ChatJoinRequestFilter) *UpdateHandler[types.ChatJoinRequest] {
+	return newUpdateHandler(func(u *types.Update) *types.ChatJoinRequest { return u.ChatJoinRequest }, "chat_join_request", fn, filter)
+}
+
+func NewChatBoostHandler(fn HandlerFunc, filter filters.ChatBoostUpdatedFilter) *UpdateHandler[types.ChatBoostUpdated] {
+	return newUpdateHandler(func(u *types.Update) *types.ChatBoostUpdated { return u.ChatBoost }, "chat_boost", fn, filter)
+}
+
+func NewRemovedChatBoostHandler(fn HandlerFunc, filter filters.ChatBoostRemovedFilter) *UpdateHandler[types.ChatBoostRemoved] {
+	return newUpdateHandler(func(u *types.Update) *types.ChatBoostRemoved { return u.RemovedChatBoost }, "removed_chat_boost", fn, filter)
+}
+
+func NewMessageReactionHandler(fn HandlerFunc, filter filters.MessageReactionUpdatedFilter) *UpdateHandler[types.MessageReactionUpdated] {
+	return newUpdateHandler(func(u *types.Update) *types.MessageReactionUpdated { return u.MessageReaction }, "message_reaction", fn, filter)
+}
+
+func NewMessageReactionCountHandler(fn HandlerFunc, filter filters.MessageReactionCountUpdatedFilter) *UpdateHandler[types.MessageReactionCountUpdated] {
+	return newUpdateHandler(func(u *types.Update) *types.MessageReactionCountUpdated { return u.MessageReactionCount }, "message_reaction_count", fn, filter)
+}
+
+func NewBusinessConnectionHandler(fn HandlerFunc, filter filters.BusinessConnectionFilter) *UpdateHandler[types.BusinessConnection] {
+	return newUpdateHandler(func(u *types.Update) *types.BusinessConnection { return u.BusinessConnection }, "business_connection", fn, filter)
+}
+
+func NewDeletedBusinessMessagesHandler(fn HandlerFunc, filter filters.BusinessMessagesDeletedFilter) *UpdateHandler[types.BusinessMessagesDeleted] {
+	return newUpdateHandler(func(u *types.Update) *types.BusinessMessagesDeleted { return u.DeletedBusinessMessages }, "deleted_business_messages", fn, filter)
+}
+
+func (d *Dispatcher) AddMessageHandler(fn HandlerFunc, filter filters.Filter) error {
+	if fn != nil {
+		d.AddHandler(NewMessageHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add messagehandler")
+	}
+}
+
+func (d *Dispatcher) AddEditedMessageHandler(fn HandlerFunc, filter filters.Filter) error {
+	if fn != nil {
+		d.AddHandler(NewEditedMessageHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add edited_message_handler")
+	}
+}
+
+func (d *Dispatcher) AddBusinessMessageHandler(fn HandlerFunc, filter filters.Filter) error {
+	if fn != nil {
+		d.AddHandler(NewBusinessMessageHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add business_message_handler")
+	}
+}
+
+func (d *Dispatcher) AddEditedBusinessMessageHandler(fn HandlerFunc, filter filters.Filter) error {
+	if fn != nil {
+		d.AddHandler(NewEditedBusinessMessageHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add edited_business_message_handler")
+	}
+}
+
+func (d *Dispatcher) AddCallbackHandler(fn HandlerFunc, filter filters.Filter) error {
+	if fn != nil {
+		d.AddHandler(NewCallbackHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add callbackhandler")
+	}
+}
+
+func (d *Dispatcher) AddInlineQueryHandler(fn HandlerFunc, filter filters.InlineQueryFilter) error {
+	if fn != nil {
+		d.AddHandler(NewInlineQueryHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add inline_query_handler")
+	}
+}
+
+func (d *Dispatcher) AddChosenInlineResultHandler(fn HandlerFunc, filter filters.ChosenInlineResultFilter) error {
+	if fn != nil {
+		d.AddHandler(NewChosenInlineResultHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add chosen_inline_result_handler")
+	}
+}
+
+func (d *Dispatcher) AddShippingQueryHandler(fn HandlerFunc, filter filters.ShippingQueryFilter) error {
+	if fn != nil {
+		d.AddHandler(NewShippingQueryHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add shipping_query_handler")
+	}
+}
+
+func (d *Dispatcher) AddPreCheckoutQueryHandler(fn HandlerFunc, filter filters.PreCheckoutQueryFilter) error {
+	if fn != nil {
+		d.AddHandler(NewPreCheckoutQueryHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add pre_checkout_query_handler")
+	}
+}
+
+func (d *Dispatcher) AddPollHandler(fn HandlerFunc, filter filters.PollFilter) error {
+	if fn != nil {
+		d.AddHandler(NewPollHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add poll_handler")
+	}
+}
+
+func (d *Dispatcher) AddPollAnswerHandler(fn HandlerFunc, filter filters.PollAnswerFilter) error {
+	if fn != nil {
+		d.AddHandler(NewPollAnswerHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add poll_answer_handler")
+	}
+}
+
+func (d *Dispatcher) AddMyChatMemberHandler(fn HandlerFunc, filter filters.ChatMemberUpdatedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewMyChatMemberHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add my_chat_member_handler")
+	}
+}
+
+func (d *Dispatcher) AddChatMemberHandler(fn HandlerFunc, filter filters.ChatMemberUpdatedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewChatMemberHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add chat_member_handler")
+	}
+}
+
+func (d *Dispatcher) AddChatJoinRequestHandler(fn HandlerFunc, filter filters.ChatJoinRequestFilter) error {
+	if fn != nil {
+		d.AddHandler(NewChatJoinRequestHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add chat_join_request_handler")
+	}
+}
+
+func (d *Dispatcher) AddChatBoostHandler(fn HandlerFunc, filter filters.ChatBoostUpdatedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewChatBoostHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add chat_boost_handler")
+	}
+}
+
+func (d *Dispatcher) AddRemovedChatBoostHandler(fn HandlerFunc, filter filters.ChatBoostRemovedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewRemovedChatBoostHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add removed_chat_boost_handler")
+	}
+}
+
+func (d *Dispatcher) AddMessageReactionHandler(fn HandlerFunc, filter filters.MessageReactionUpdatedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewMessageReactionHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add message_reaction_handler")
+	}
+}
+
+func (d *Dispatcher) AddMessageReactionCountHandler(fn HandlerFunc, filter filters.MessageReactionCountUpdatedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewMessageReactionCountHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add message_reaction_count_handler")
+	}
+}
+
+func (d *Dispatcher) AddBusinessConnectionHandler(fn HandlerFunc, filter filters.BusinessConnectionFilter) error {
+	if fn != nil {
+		d.AddHandler(NewBusinessConnectionHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add business_connection_handler")
+	}
+}
+
+func (d *Dispatcher) AddDeletedBusinessMessagesHandler(fn HandlerFunc, filter filters.BusinessMessagesDeletedFilter) error {
+	if fn != nil {
+		d.AddHandler(NewDeletedBusinessMessagesHandler(fn, filter))
+		return nil
+	} else {
+		return fmt.Errorf("failed to add deleted_business_messages_handler")
+	}
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+func TestUpdateHandler(t *testing.T) {
+	noop := func(b *Bot, ctx *Context) error { return nil }
+	pollUpdate := &types.Update{UpdateId: 1, Poll: &types.Poll{Id: "1", IsClosed: true}}
+
+	tests := []struct {
+		name    string
+		handler Handler
+		update  *types.Update
+		want    bool
+	}{
+		{"nil filter", NewPollHandler(noop, nil), pollUpdate, true},
+		{"filter matches", NewPollHandler(noop, func(p *types.Poll) bool { return p.IsClosed }), pollUpdate, true},
+		{"filter rejects", NewPollHandler(noop, func(p *types.Poll) bool { return !p.IsClosed }), pollUpdate, false},
+		{"other update kind", NewPollHandler(noop, nil), textUpdate("hello"), false},
+		{"struct literal", &UpdateHandler[types.Poll]{Function: noop}, pollUpdate, false},
+		{"message struct literal", &MessageHandlers{Function: noop}, textUpdate("hello"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := tt.handler.CheckUpdate(nil, NewContext(context.Background(), tt.update)); got != tt.want {
+				t.Errorf("CheckUpdate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerUpdateTypes(t *testing.T) {
+	noop := func(b *Bot, ctx *Context) error { return nil }
+	handlers := []Handler{
+		NewMessageHandler(noop, nil),
+		NewCallbackHandler(noop, nil),
+		NewMyChatMemberHandler(noop, nil),
+		NewChatMemberHandler(noop, nil),
+	}
+
+	want := []string{"message", "channel_post", "callback_query", "my_chat_member", "chat_member"}
+	if got := handlerUpdateTypes(handlers); !reflect.DeepEqual(got, want) {
+		t.Errorf("handlerUpdateTypes = %q, want %q", got, want)
+	}
+}
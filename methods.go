@@ -1,7 +1,7 @@
 package bot
 
 import (
-    "os"
+    "context"
     "fmt"
     "strconv"
     "encoding/json"
@@ -19,8 +19,13 @@ type GetUpdatesOpts struct {
 
 // Use this method to receive incoming updates using long polling (wiki). Returns an Array of Update objects.
 func (b *Bot) GetUpdates(opts *GetUpdatesOpts) ([]types.Update, error) {
+    return b.GetUpdatesWithContext(context.Background(), opts)
+}
+
+// GetUpdatesWithContext is the same as GetUpdates, but uses the given context for the underlying request.
+func (b *Bot) GetUpdatesWithContext(ctx context.Context, opts *GetUpdatesOpts) ([]types.Update, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["offset"] = strconv.FormatInt(opts.Offset, 10)
@@ -38,7 +43,7 @@ func (b *Bot) GetUpdates(opts *GetUpdatesOpts) ([]types.Update, error) {
     }
 
 
-    r, err := b.Request("getUpdates", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getUpdates", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -62,24 +67,23 @@ type SetWebhookOpts struct {
 // Use this method to specify a URL and receive incoming updates via an outgoing webhook. Whenever there is an update for the bot, we will send an HTTPS POST request to the specified URL, containing a JSON-serialized Update. In case of an unsuccessful request, we will give up after a reasonable amount of attempts. Returns True on success.
 // If you'd like to make sure that the webhook was set by you, you can specify secret data in the parameter secret_token. If specified, the request will contain a header "X-Telegram-Bot-Api-Secret-Token" with the secret token as content.
 func (b *Bot) SetWebhook(url string, opts *SetWebhookOpts) (bool, error) {
+    return b.SetWebhookWithContext(context.Background(), url, opts)
+}
+
+// SetWebhookWithContext is the same as SetWebhook, but uses the given context for the underlying request.
+func (b *Bot) SetWebhookWithContext(ctx context.Context, url string, opts *SetWebhookOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["url"] = url
     if opts != nil {
 
         if opts.Certificate != nil {
-            switch f := opts.Certificate.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["certificate"] = f
-                } else {
-                    params["certificate"] = "attach://certificate"
-                    data_params["certificate"] = f
-                }
-            default:
-                return false, fmt.Errorf("unknown type for InputFile: %T", opts.Certificate)
+            if ref, ok := opts.Certificate.Reference(); ok {
+                params["certificate"] = ref
+            } else {
+                params["certificate"] = "attach://certificate"
+                data_params["certificate"] = opts.Certificate
             }
         }
         params["ip_address"] = opts.IpAddress
@@ -98,7 +102,7 @@ func (b *Bot) SetWebhook(url string, opts *SetWebhookOpts) (bool, error) {
     }
 
 
-    r, err := b.Request("setWebhook", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setWebhook", params, data_params)
     if err != nil {
         return false, err
     }
@@ -116,15 +120,20 @@ type DeleteWebhookOpts struct {
 
 // Use this method to remove webhook integration if you decide to switch back to getUpdates. Returns True on success.
 func (b *Bot) DeleteWebhook(opts *DeleteWebhookOpts) (bool, error) {
+    return b.DeleteWebhookWithContext(context.Background(), opts)
+}
+
+// DeleteWebhookWithContext is the same as DeleteWebhook, but uses the given context for the underlying request.
+func (b *Bot) DeleteWebhookWithContext(ctx context.Context, opts *DeleteWebhookOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["drop_pending_updates"] = strconv.FormatBool(opts.DropPendingUpdates)
     }
 
 
-    r, err := b.Request("deleteWebhook", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteWebhook", params, data_params)
     if err != nil {
         return false, err
     }
@@ -137,10 +146,15 @@ func (b *Bot) DeleteWebhook(opts *DeleteWebhookOpts) (bool, error) {
 
 // Use this method to get current webhook status. Requires no parameters. On success, returns a WebhookInfo object. If the bot is using getUpdates, will return an object with the url field empty.
 func (b *Bot) GetWebhookInfo() (*types.WebhookInfo, error) {
+    return b.GetWebhookInfoWithContext(context.Background())
+}
+
+// GetWebhookInfoWithContext is the same as GetWebhookInfo, but uses the given context for the underlying request.
+func (b *Bot) GetWebhookInfoWithContext(ctx context.Context) (*types.WebhookInfo, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    r, err := b.Request("getWebhookInfo", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getWebhookInfo", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -152,10 +166,15 @@ func (b *Bot) GetWebhookInfo() (*types.WebhookInfo, error) {
 
 // A simple method for testing your bot's authentication token. Requires no parameters. Returns basic information about the bot in form of a User object.
 func (b *Bot) GetMe() (*types.User, error) {
+    return b.GetMeWithContext(context.Background())
+}
+
+// GetMeWithContext is the same as GetMe, but uses the given context for the underlying request.
+func (b *Bot) GetMeWithContext(ctx context.Context) (*types.User, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    r, err := b.Request("getMe", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getMe", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -167,10 +186,15 @@ func (b *Bot) GetMe() (*types.User, error) {
 
 // Use this method to log out from the cloud Bot API server before launching the bot locally. You must log out the bot before running it locally, otherwise there is no guarantee that the bot will receive updates. After a successful call, you can immediately log in on a local server, but will not be able to log in back to the cloud Bot API server for 10 minutes. Returns True on success. Requires no parameters.
 func (b *Bot) LogOut() (bool, error) {
+    return b.LogOutWithContext(context.Background())
+}
+
+// LogOutWithContext is the same as LogOut, but uses the given context for the underlying request.
+func (b *Bot) LogOutWithContext(ctx context.Context) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    r, err := b.Request("logOut", params, data_params)
+    r, err := b.RequestWithContext(ctx, "logOut", params, data_params)
     if err != nil {
         return false, err
     }
@@ -182,10 +206,15 @@ func (b *Bot) LogOut() (bool, error) {
 
 // Use this method to close the bot instance before moving it from one local server to another. You need to delete the webhook before calling this method to ensure that the bot isn't launched again after server restart. The method will return error 429 in the first 10 minutes after the bot is launched. Returns True on success. Requires no parameters.
 func (b *Bot) Close() (bool, error) {
+    return b.CloseWithContext(context.Background())
+}
+
+// CloseWithContext is the same as Close, but uses the given context for the underlying request.
+func (b *Bot) CloseWithContext(ctx context.Context) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    r, err := b.Request("close", params, data_params)
+    r, err := b.RequestWithContext(ctx, "close", params, data_params)
     if err != nil {
         return false, err
     }
@@ -210,11 +239,16 @@ type SendMessageOpts struct {
 }
 
 // Use this method to send text messages. On success, the sent Message is returned.
-func (b *Bot) SendMessage(chatId int64, text string, opts *SendMessageOpts) (*types.Message, error) {
+func (b *Bot) SendMessage(chatId ChatID, text string, opts *SendMessageOpts) (*types.Message, error) {
+    return b.SendMessageWithContext(context.Background(), chatId, text, opts)
+}
+
+// SendMessageWithContext is the same as SendMessage, but uses the given context for the underlying request.
+func (b *Bot) SendMessageWithContext(ctx context.Context, chatId ChatID, text string, opts *SendMessageOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["text"] = text
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
@@ -262,7 +296,7 @@ func (b *Bot) SendMessage(chatId int64, text string, opts *SendMessageOpts) (*ty
     }
 
 
-    r, err := b.Request("sendMessage", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendMessage", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -281,12 +315,17 @@ type ForwardMessageOpts struct {
 }
 
 // Use this method to forward messages of any kind. Service messages and messages with protected content can't be forwarded. On success, the sent Message is returned.
-func (b *Bot) ForwardMessage(chatId int64, fromChatId int64, messageId int64, opts *ForwardMessageOpts) (*types.Message, error) {
+func (b *Bot) ForwardMessage(chatId ChatID, fromChatId ChatID, messageId int64, opts *ForwardMessageOpts) (*types.Message, error) {
+    return b.ForwardMessageWithContext(context.Background(), chatId, fromChatId, messageId, opts)
+}
+
+// ForwardMessageWithContext is the same as ForwardMessage, but uses the given context for the underlying request.
+func (b *Bot) ForwardMessageWithContext(ctx context.Context, chatId ChatID, fromChatId ChatID, messageId int64, opts *ForwardMessageOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
-    params["from_chat_id"] = strconv.FormatInt(fromChatId, 10)
+    params["chat_id"] = chatId.String()
+    params["from_chat_id"] = fromChatId.String()
     params["message_id"] = strconv.FormatInt(messageId, 10)
     if opts != nil {
         params["message_thread_id"] = strconv.FormatInt(opts.MessageThreadId, 10)
@@ -295,7 +334,7 @@ func (b *Bot) ForwardMessage(chatId int64, fromChatId int64, messageId int64, op
     }
 
 
-    r, err := b.Request("forwardMessage", params, data_params)
+    r, err := b.RequestWithContext(ctx, "forwardMessage", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -314,12 +353,17 @@ type ForwardMessagesOpts struct {
 }
 
 // Use this method to forward multiple messages of any kind. If some of the specified messages can't be found or forwarded, they are skipped. Service messages and messages with protected content can't be forwarded. Album grouping is kept for forwarded messages. On success, an array of MessageId of the sent messages is returned.
-func (b *Bot) ForwardMessages(chatId int64, fromChatId int64, messageIds []int64, opts *ForwardMessagesOpts) ([]types.MessageId, error) {
+func (b *Bot) ForwardMessages(chatId ChatID, fromChatId ChatID, messageIds []int64, opts *ForwardMessagesOpts) ([]types.MessageId, error) {
+    return b.ForwardMessagesWithContext(context.Background(), chatId, fromChatId, messageIds, opts)
+}
+
+// ForwardMessagesWithContext is the same as ForwardMessages, but uses the given context for the underlying request.
+func (b *Bot) ForwardMessagesWithContext(ctx context.Context, chatId ChatID, fromChatId ChatID, messageIds []int64, opts *ForwardMessagesOpts) ([]types.MessageId, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
-    params["from_chat_id"] = strconv.FormatInt(fromChatId, 10)
+    params["chat_id"] = chatId.String()
+    params["from_chat_id"] = fromChatId.String()
 
     if messageIds != nil {
         bs, err := json.Marshal(messageIds)
@@ -336,7 +380,7 @@ func (b *Bot) ForwardMessages(chatId int64, fromChatId int64, messageIds []int64
     }
 
 
-    r, err := b.Request("forwardMessages", params, data_params)
+    r, err := b.RequestWithContext(ctx, "forwardMessages", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -361,12 +405,17 @@ type CopyMessageOpts struct {
 }
 
 // Use this method to copy messages of any kind. Service messages, paid media messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. A quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The method is analogous to the method forwardMessage, but the copied message doesn't have a link to the original message. Returns the MessageId of the sent message on success.
-func (b *Bot) CopyMessage(chatId int64, fromChatId int64, messageId int64, opts *CopyMessageOpts) (*types.MessageId, error) {
+func (b *Bot) CopyMessage(chatId ChatID, fromChatId ChatID, messageId int64, opts *CopyMessageOpts) (*types.MessageId, error) {
+    return b.CopyMessageWithContext(context.Background(), chatId, fromChatId, messageId, opts)
+}
+
+// CopyMessageWithContext is the same as CopyMessage, but uses the given context for the underlying request.
+func (b *Bot) CopyMessageWithContext(ctx context.Context, chatId ChatID, fromChatId ChatID, messageId int64, opts *CopyMessageOpts) (*types.MessageId, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
-    params["from_chat_id"] = strconv.FormatInt(fromChatId, 10)
+    params["chat_id"] = chatId.String()
+    params["from_chat_id"] = fromChatId.String()
     params["message_id"] = strconv.FormatInt(messageId, 10)
     if opts != nil {
         params["message_thread_id"] = strconv.FormatInt(opts.MessageThreadId, 10)
@@ -405,7 +454,7 @@ func (b *Bot) CopyMessage(chatId int64, fromChatId int64, messageId int64, opts
     }
 
 
-    r, err := b.Request("copyMessage", params, data_params)
+    r, err := b.RequestWithContext(ctx, "copyMessage", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -425,12 +474,17 @@ type CopyMessagesOpts struct {
 }
 
 // Use this method to copy messages of any kind. If some of the specified messages can't be found or copied, they are skipped. Service messages, paid media messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. A quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The method is analogous to the method forwardMessages, but the copied messages don't have a link to the original message. Album grouping is kept for copied messages. On success, an array of MessageId of the sent messages is returned.
-func (b *Bot) CopyMessages(chatId int64, fromChatId int64, messageIds []int64, opts *CopyMessagesOpts) ([]types.MessageId, error) {
+func (b *Bot) CopyMessages(chatId ChatID, fromChatId ChatID, messageIds []int64, opts *CopyMessagesOpts) ([]types.MessageId, error) {
+    return b.CopyMessagesWithContext(context.Background(), chatId, fromChatId, messageIds, opts)
+}
+
+// CopyMessagesWithContext is the same as CopyMessages, but uses the given context for the underlying request.
+func (b *Bot) CopyMessagesWithContext(ctx context.Context, chatId ChatID, fromChatId ChatID, messageIds []int64, opts *CopyMessagesOpts) ([]types.MessageId, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
-    params["from_chat_id"] = strconv.FormatInt(fromChatId, 10)
+    params["chat_id"] = chatId.String()
+    params["from_chat_id"] = fromChatId.String()
 
     if messageIds != nil {
         bs, err := json.Marshal(messageIds)
@@ -448,7 +502,7 @@ func (b *Bot) CopyMessages(chatId int64, fromChatId int64, messageIds []int64, o
     }
 
 
-    r, err := b.Request("copyMessages", params, data_params)
+    r, err := b.RequestWithContext(ctx, "copyMessages", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -476,24 +530,23 @@ type SendPhotoOpts struct {
 }
 
 // Use this method to send photos. On success, the sent Message is returned.
-func (b *Bot) SendPhoto(chatId int64, photo types.InputFile, opts *SendPhotoOpts) (*types.Message, error) {
+func (b *Bot) SendPhoto(chatId ChatID, photo types.InputFile, opts *SendPhotoOpts) (*types.Message, error) {
+    return b.SendPhotoWithContext(context.Background(), chatId, photo, opts)
+}
+
+// SendPhotoWithContext is the same as SendPhoto, but uses the given context for the underlying request.
+func (b *Bot) SendPhotoWithContext(ctx context.Context, chatId ChatID, photo types.InputFile, opts *SendPhotoOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if photo != nil {
-        switch f := photo.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["photo"] = f
-            } else {
-                params["photo"] = "attach://photo"
-                data_params["photo"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", photo)
+        if ref, ok := photo.Reference(); ok {
+            params["photo"] = ref
+        } else {
+            params["photo"] = "attach://photo"
+            data_params["photo"] = photo
         }
     }
     if opts != nil {
@@ -536,7 +589,7 @@ func (b *Bot) SendPhoto(chatId int64, photo types.InputFile, opts *SendPhotoOpts
     }
 
 
-    r, err := b.Request("sendPhoto", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendPhoto", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -567,24 +620,23 @@ type SendAudioOpts struct {
 
 // Use this method to send audio files, if you want Telegram clients to display them in the music player. Your audio must be in the .MP3 or .M4A format. On success, the sent Message is returned. Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
 // For sending voice messages, use the sendVoice method instead.
-func (b *Bot) SendAudio(chatId int64, audio types.InputFile, opts *SendAudioOpts) (*types.Message, error) {
+func (b *Bot) SendAudio(chatId ChatID, audio types.InputFile, opts *SendAudioOpts) (*types.Message, error) {
+    return b.SendAudioWithContext(context.Background(), chatId, audio, opts)
+}
+
+// SendAudioWithContext is the same as SendAudio, but uses the given context for the underlying request.
+func (b *Bot) SendAudioWithContext(ctx context.Context, chatId ChatID, audio types.InputFile, opts *SendAudioOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if audio != nil {
-        switch f := audio.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["audio"] = f
-            } else {
-                params["audio"] = "attach://audio"
-                data_params["audio"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", audio)
+        if ref, ok := audio.Reference(); ok {
+            params["audio"] = ref
+        } else {
+            params["audio"] = "attach://audio"
+            data_params["audio"] = audio
         }
     }
     if opts != nil {
@@ -606,17 +658,11 @@ func (b *Bot) SendAudio(chatId int64, audio types.InputFile, opts *SendAudioOpts
         params["title"] = opts.Title
 
         if opts.Thumbnail != nil {
-            switch f := opts.Thumbnail.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["thumbnail"] = f
-                } else {
-                    params["thumbnail"] = "attach://thumbnail"
-                    data_params["thumbnail"] = f
-                }
-            default:
-                return nil, fmt.Errorf("unknown type for InputFile: %T", opts.Thumbnail)
+            if ref, ok := opts.Thumbnail.Reference(); ok {
+                params["thumbnail"] = ref
+            } else {
+                params["thumbnail"] = "attach://thumbnail"
+                data_params["thumbnail"] = opts.Thumbnail
             }
         }
         params["disable_notification"] = strconv.FormatBool(opts.DisableNotification)
@@ -643,7 +689,7 @@ func (b *Bot) SendAudio(chatId int64, audio types.InputFile, opts *SendAudioOpts
     }
 
 
-    r, err := b.Request("sendAudio", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendAudio", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -671,24 +717,23 @@ type SendDocumentOpts struct {
 }
 
 // Use this method to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
-func (b *Bot) SendDocument(chatId int64, document types.InputFile, opts *SendDocumentOpts) (*types.Message, error) {
+func (b *Bot) SendDocument(chatId ChatID, document types.InputFile, opts *SendDocumentOpts) (*types.Message, error) {
+    return b.SendDocumentWithContext(context.Background(), chatId, document, opts)
+}
+
+// SendDocumentWithContext is the same as SendDocument, but uses the given context for the underlying request.
+func (b *Bot) SendDocumentWithContext(ctx context.Context, chatId ChatID, document types.InputFile, opts *SendDocumentOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if document != nil {
-        switch f := document.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["document"] = f
-            } else {
-                params["document"] = "attach://document"
-                data_params["document"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", document)
+        if ref, ok := document.Reference(); ok {
+            params["document"] = ref
+        } else {
+            params["document"] = "attach://document"
+            data_params["document"] = document
         }
     }
     if opts != nil {
@@ -696,17 +741,11 @@ func (b *Bot) SendDocument(chatId int64, document types.InputFile, opts *SendDoc
         params["message_thread_id"] = strconv.FormatInt(opts.MessageThreadId, 10)
 
         if opts.Thumbnail != nil {
-            switch f := opts.Thumbnail.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["thumbnail"] = f
-                } else {
-                    params["thumbnail"] = "attach://thumbnail"
-                    data_params["thumbnail"] = f
-                }
-            default:
-                return nil, fmt.Errorf("unknown type for InputFile: %T", opts.Thumbnail)
+            if ref, ok := opts.Thumbnail.Reference(); ok {
+                params["thumbnail"] = ref
+            } else {
+                params["thumbnail"] = "attach://thumbnail"
+                data_params["thumbnail"] = opts.Thumbnail
             }
         }
         params["caption"] = opts.Caption
@@ -745,7 +784,7 @@ func (b *Bot) SendDocument(chatId int64, document types.InputFile, opts *SendDoc
     }
 
 
-    r, err := b.Request("sendDocument", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendDocument", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -778,24 +817,23 @@ type SendVideoOpts struct {
 }
 
 // Use this method to send video files, Telegram clients support MPEG4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
-func (b *Bot) SendVideo(chatId int64, video types.InputFile, opts *SendVideoOpts) (*types.Message, error) {
+func (b *Bot) SendVideo(chatId ChatID, video types.InputFile, opts *SendVideoOpts) (*types.Message, error) {
+    return b.SendVideoWithContext(context.Background(), chatId, video, opts)
+}
+
+// SendVideoWithContext is the same as SendVideo, but uses the given context for the underlying request.
+func (b *Bot) SendVideoWithContext(ctx context.Context, chatId ChatID, video types.InputFile, opts *SendVideoOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if video != nil {
-        switch f := video.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["video"] = f
-            } else {
-                params["video"] = "attach://video"
-                data_params["video"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", video)
+        if ref, ok := video.Reference(); ok {
+            params["video"] = ref
+        } else {
+            params["video"] = "attach://video"
+            data_params["video"] = video
         }
     }
     if opts != nil {
@@ -806,17 +844,11 @@ func (b *Bot) SendVideo(chatId int64, video types.InputFile, opts *SendVideoOpts
         params["height"] = strconv.FormatInt(opts.Height, 10)
 
         if opts.Thumbnail != nil {
-            switch f := opts.Thumbnail.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["thumbnail"] = f
-                } else {
-                    params["thumbnail"] = "attach://thumbnail"
-                    data_params["thumbnail"] = f
-                }
-            default:
-                return nil, fmt.Errorf("unknown type for InputFile: %T", opts.Thumbnail)
+            if ref, ok := opts.Thumbnail.Reference(); ok {
+                params["thumbnail"] = ref
+            } else {
+                params["thumbnail"] = "attach://thumbnail"
+                data_params["thumbnail"] = opts.Thumbnail
             }
         }
         params["caption"] = opts.Caption
@@ -857,7 +889,7 @@ func (b *Bot) SendVideo(chatId int64, video types.InputFile, opts *SendVideoOpts
     }
 
 
-    r, err := b.Request("sendVideo", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendVideo", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -889,24 +921,23 @@ type SendAnimationOpts struct {
 }
 
 // Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
-func (b *Bot) SendAnimation(chatId int64, animation types.InputFile, opts *SendAnimationOpts) (*types.Message, error) {
+func (b *Bot) SendAnimation(chatId ChatID, animation types.InputFile, opts *SendAnimationOpts) (*types.Message, error) {
+    return b.SendAnimationWithContext(context.Background(), chatId, animation, opts)
+}
+
+// SendAnimationWithContext is the same as SendAnimation, but uses the given context for the underlying request.
+func (b *Bot) SendAnimationWithContext(ctx context.Context, chatId ChatID, animation types.InputFile, opts *SendAnimationOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if animation != nil {
-        switch f := animation.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["animation"] = f
-            } else {
-                params["animation"] = "attach://animation"
-                data_params["animation"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", animation)
+        if ref, ok := animation.Reference(); ok {
+            params["animation"] = ref
+        } else {
+            params["animation"] = "attach://animation"
+            data_params["animation"] = animation
         }
     }
     if opts != nil {
@@ -917,17 +948,11 @@ func (b *Bot) SendAnimation(chatId int64, animation types.InputFile, opts *SendA
         params["height"] = strconv.FormatInt(opts.Height, 10)
 
         if opts.Thumbnail != nil {
-            switch f := opts.Thumbnail.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["thumbnail"] = f
-                } else {
-                    params["thumbnail"] = "attach://thumbnail"
-                    data_params["thumbnail"] = f
-                }
-            default:
-                return nil, fmt.Errorf("unknown type for InputFile: %T", opts.Thumbnail)
+            if ref, ok := opts.Thumbnail.Reference(); ok {
+                params["thumbnail"] = ref
+            } else {
+                params["thumbnail"] = "attach://thumbnail"
+                data_params["thumbnail"] = opts.Thumbnail
             }
         }
         params["caption"] = opts.Caption
@@ -967,7 +992,7 @@ func (b *Bot) SendAnimation(chatId int64, animation types.InputFile, opts *SendA
     }
 
 
-    r, err := b.Request("sendAnimation", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendAnimation", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -994,24 +1019,23 @@ type SendVoiceOpts struct {
 }
 
 // Use this method to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS, or in .MP3 format, or in .M4A format (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
-func (b *Bot) SendVoice(chatId int64, voice types.InputFile, opts *SendVoiceOpts) (*types.Message, error) {
+func (b *Bot) SendVoice(chatId ChatID, voice types.InputFile, opts *SendVoiceOpts) (*types.Message, error) {
+    return b.SendVoiceWithContext(context.Background(), chatId, voice, opts)
+}
+
+// SendVoiceWithContext is the same as SendVoice, but uses the given context for the underlying request.
+func (b *Bot) SendVoiceWithContext(ctx context.Context, chatId ChatID, voice types.InputFile, opts *SendVoiceOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if voice != nil {
-        switch f := voice.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["voice"] = f
-            } else {
-                params["voice"] = "attach://voice"
-                data_params["voice"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", voice)
+        if ref, ok := voice.Reference(); ok {
+            params["voice"] = ref
+        } else {
+            params["voice"] = "attach://voice"
+            data_params["voice"] = voice
         }
     }
     if opts != nil {
@@ -1053,7 +1077,7 @@ func (b *Bot) SendVoice(chatId int64, voice types.InputFile, opts *SendVoiceOpts
     }
 
 
-    r, err := b.Request("sendVoice", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendVoice", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1079,24 +1103,23 @@ type SendVideoNoteOpts struct {
 }
 
 // As of v.4.0, Telegram clients support rounded square MPEG4 videos of up to 1 minute long. Use this method to send video messages. On success, the sent Message is returned.
-func (b *Bot) SendVideoNote(chatId int64, videoNote types.InputFile, opts *SendVideoNoteOpts) (*types.Message, error) {
+func (b *Bot) SendVideoNote(chatId ChatID, videoNote types.InputFile, opts *SendVideoNoteOpts) (*types.Message, error) {
+    return b.SendVideoNoteWithContext(context.Background(), chatId, videoNote, opts)
+}
+
+// SendVideoNoteWithContext is the same as SendVideoNote, but uses the given context for the underlying request.
+func (b *Bot) SendVideoNoteWithContext(ctx context.Context, chatId ChatID, videoNote types.InputFile, opts *SendVideoNoteOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if videoNote != nil {
-        switch f := videoNote.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["video_note"] = f
-            } else {
-                params["video_note"] = "attach://video_note"
-                data_params["video_note"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", videoNote)
+        if ref, ok := videoNote.Reference(); ok {
+            params["video_note"] = ref
+        } else {
+            params["video_note"] = "attach://video_note"
+            data_params["video_note"] = videoNote
         }
     }
     if opts != nil {
@@ -1106,17 +1129,11 @@ func (b *Bot) SendVideoNote(chatId int64, videoNote types.InputFile, opts *SendV
         params["length"] = strconv.FormatInt(opts.Length, 10)
 
         if opts.Thumbnail != nil {
-            switch f := opts.Thumbnail.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["thumbnail"] = f
-                } else {
-                    params["thumbnail"] = "attach://thumbnail"
-                    data_params["thumbnail"] = f
-                }
-            default:
-                return nil, fmt.Errorf("unknown type for InputFile: %T", opts.Thumbnail)
+            if ref, ok := opts.Thumbnail.Reference(); ok {
+                params["thumbnail"] = ref
+            } else {
+                params["thumbnail"] = "attach://thumbnail"
+                data_params["thumbnail"] = opts.Thumbnail
             }
         }
         params["disable_notification"] = strconv.FormatBool(opts.DisableNotification)
@@ -1143,7 +1160,7 @@ func (b *Bot) SendVideoNote(chatId int64, videoNote types.InputFile, opts *SendV
     }
 
 
-    r, err := b.Request("sendVideoNote", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendVideoNote", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1167,15 +1184,28 @@ type SendPaidMediaOpts struct {
 }
 
 // Use this method to send paid media to channel chats. On success, the sent Message is returned.
-func (b *Bot) SendPaidMedia(chatId int64, starCount int64, media []types.InputPaidMedia, opts *SendPaidMediaOpts) (*types.Message, error) {
+func (b *Bot) SendPaidMedia(chatId ChatID, starCount int64, media []types.InputPaidMedia, opts *SendPaidMediaOpts) (*types.Message, error) {
+    return b.SendPaidMediaWithContext(context.Background(), chatId, starCount, media, opts)
+}
+
+// SendPaidMediaWithContext is the same as SendPaidMedia, but uses the given context for the underlying request.
+func (b *Bot) SendPaidMediaWithContext(ctx context.Context, chatId ChatID, starCount int64, media []types.InputPaidMedia, opts *SendPaidMediaOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["star_count"] = strconv.FormatInt(starCount, 10)
 
     if media != nil {
-        bs, err := json.Marshal(media)
+        items := make([]json.RawMessage, len(media))
+        for i, v := range media {
+            bs, err := v.InputParams("media" + strconv.Itoa(i), data_params)
+            if err != nil {
+                return nil, fmt.Errorf("failed to marshal field media: %w", err)
+            }
+            items[i] = bs
+        }
+        bs, err := json.Marshal(items)
         if err != nil {
             return nil, fmt.Errorf("failed to marshal field media: %w", err)
         }
@@ -1218,7 +1248,7 @@ func (b *Bot) SendPaidMedia(chatId int64, starCount int64, media []types.InputPa
     }
 
 
-    r, err := b.Request("sendPaidMedia", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendPaidMedia", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1240,14 +1270,27 @@ type SendMediaGroupOpts struct {
 }
 
 // Use this method to send a group of photos, videos, documents or audios as an album. Documents and audio files can be only grouped in an album with messages of the same type. On success, an array of Messages that were sent is returned.
-func (b *Bot) SendMediaGroup(chatId int64, media []types.InputMediaAudio, opts *SendMediaGroupOpts) ([]types.Message, error) {
+func (b *Bot) SendMediaGroup(chatId ChatID, media []types.InputMedia, opts *SendMediaGroupOpts) ([]types.Message, error) {
+    return b.SendMediaGroupWithContext(context.Background(), chatId, media, opts)
+}
+
+// SendMediaGroupWithContext is the same as SendMediaGroup, but uses the given context for the underlying request.
+func (b *Bot) SendMediaGroupWithContext(ctx context.Context, chatId ChatID, media []types.InputMedia, opts *SendMediaGroupOpts) ([]types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if media != nil {
-        bs, err := json.Marshal(media)
+        items := make([]json.RawMessage, len(media))
+        for i, v := range media {
+            bs, err := v.InputParams("media" + strconv.Itoa(i), data_params)
+            if err != nil {
+                return nil, fmt.Errorf("failed to marshal field media: %w", err)
+            }
+            items[i] = bs
+        }
+        bs, err := json.Marshal(items)
         if err != nil {
             return nil, fmt.Errorf("failed to marshal field media: %w", err)
         }
@@ -1272,7 +1315,7 @@ func (b *Bot) SendMediaGroup(chatId int64, media []types.InputMediaAudio, opts *
     }
 
 
-    r, err := b.Request("sendMediaGroup", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendMediaGroup", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1299,11 +1342,16 @@ type SendLocationOpts struct {
 }
 
 // Use this method to send point on the map. On success, the sent Message is returned.
-func (b *Bot) SendLocation(chatId int64, latitude float64, longitude float64, opts *SendLocationOpts) (*types.Message, error) {
+func (b *Bot) SendLocation(chatId ChatID, latitude float64, longitude float64, opts *SendLocationOpts) (*types.Message, error) {
+    return b.SendLocationWithContext(context.Background(), chatId, latitude, longitude, opts)
+}
+
+// SendLocationWithContext is the same as SendLocation, but uses the given context for the underlying request.
+func (b *Bot) SendLocationWithContext(ctx context.Context, chatId ChatID, latitude float64, longitude float64, opts *SendLocationOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["latitude"] = strconv.FormatFloat(latitude, 'E', -1, 64)
     params["longitude"] = strconv.FormatFloat(longitude, 'E', -1, 64)
     if opts != nil {
@@ -1337,7 +1385,7 @@ func (b *Bot) SendLocation(chatId int64, latitude float64, longitude float64, op
     }
 
 
-    r, err := b.Request("sendLocation", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendLocation", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1364,11 +1412,16 @@ type SendVenueOpts struct {
 }
 
 // Use this method to send information about a venue. On success, the sent Message is returned.
-func (b *Bot) SendVenue(chatId int64, latitude float64, longitude float64, title string, address string, opts *SendVenueOpts) (*types.Message, error) {
+func (b *Bot) SendVenue(chatId ChatID, latitude float64, longitude float64, title string, address string, opts *SendVenueOpts) (*types.Message, error) {
+    return b.SendVenueWithContext(context.Background(), chatId, latitude, longitude, title, address, opts)
+}
+
+// SendVenueWithContext is the same as SendVenue, but uses the given context for the underlying request.
+func (b *Bot) SendVenueWithContext(ctx context.Context, chatId ChatID, latitude float64, longitude float64, title string, address string, opts *SendVenueOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["latitude"] = strconv.FormatFloat(latitude, 'E', -1, 64)
     params["longitude"] = strconv.FormatFloat(longitude, 'E', -1, 64)
     params["title"] = title
@@ -1404,7 +1457,7 @@ func (b *Bot) SendVenue(chatId int64, latitude float64, longitude float64, title
     }
 
 
-    r, err := b.Request("sendVenue", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendVenue", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1429,11 +1482,16 @@ type SendContactOpts struct {
 }
 
 // Use this method to send phone contacts. On success, the sent Message is returned.
-func (b *Bot) SendContact(chatId int64, phoneNumber string, firstName string, opts *SendContactOpts) (*types.Message, error) {
+func (b *Bot) SendContact(chatId ChatID, phoneNumber string, firstName string, opts *SendContactOpts) (*types.Message, error) {
+    return b.SendContactWithContext(context.Background(), chatId, phoneNumber, firstName, opts)
+}
+
+// SendContactWithContext is the same as SendContact, but uses the given context for the underlying request.
+func (b *Bot) SendContactWithContext(ctx context.Context, chatId ChatID, phoneNumber string, firstName string, opts *SendContactOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["phone_number"] = phoneNumber
     params["first_name"] = firstName
     if opts != nil {
@@ -1465,7 +1523,7 @@ func (b *Bot) SendContact(chatId int64, phoneNumber string, firstName string, op
     }
 
 
-    r, err := b.Request("sendContact", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendContact", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1500,11 +1558,16 @@ type SendPollOpts struct {
 }
 
 // Use this method to send a native poll. On success, the sent Message is returned.
-func (b *Bot) SendPoll(chatId int64, question string, options []types.InputPollOption, opts *SendPollOpts) (*types.Message, error) {
+func (b *Bot) SendPoll(chatId ChatID, question string, options []types.InputPollOption, opts *SendPollOpts) (*types.Message, error) {
+    return b.SendPollWithContext(context.Background(), chatId, question, options, opts)
+}
+
+// SendPollWithContext is the same as SendPoll, but uses the given context for the underlying request.
+func (b *Bot) SendPollWithContext(ctx context.Context, chatId ChatID, question string, options []types.InputPollOption, opts *SendPollOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["question"] = question
 
     if options != nil {
@@ -1570,7 +1633,7 @@ func (b *Bot) SendPoll(chatId int64, question string, options []types.InputPollO
     }
 
 
-    r, err := b.Request("sendPoll", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendPoll", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1594,11 +1657,16 @@ type SendDiceOpts struct {
 }
 
 // Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned.
-func (b *Bot) SendDice(chatId int64, opts *SendDiceOpts) (*types.Message, error) {
+func (b *Bot) SendDice(chatId ChatID, opts *SendDiceOpts) (*types.Message, error) {
+    return b.SendDiceWithContext(context.Background(), chatId, opts)
+}
+
+// SendDiceWithContext is the same as SendDice, but uses the given context for the underlying request.
+func (b *Bot) SendDiceWithContext(ctx context.Context, chatId ChatID, opts *SendDiceOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
         params["message_thread_id"] = strconv.FormatInt(opts.MessageThreadId, 10)
@@ -1627,7 +1695,7 @@ func (b *Bot) SendDice(chatId int64, opts *SendDiceOpts) (*types.Message, error)
     }
 
 
-    r, err := b.Request("sendDice", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendDice", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1646,11 +1714,16 @@ type SendChatActionOpts struct {
 
 // Use this method when you need to tell the user that something is happening on the bot's side. The status is set for 5 seconds or less (when a message arrives from your bot, Telegram clients clear its typing status). Returns True on success.
 // We only recommend using this method when a response from the bot will take a noticeable amount of time to arrive.
-func (b *Bot) SendChatAction(chatId int64, action string, opts *SendChatActionOpts) (bool, error) {
+func (b *Bot) SendChatAction(chatId ChatID, action string, opts *SendChatActionOpts) (bool, error) {
+    return b.SendChatActionWithContext(context.Background(), chatId, action, opts)
+}
+
+// SendChatActionWithContext is the same as SendChatAction, but uses the given context for the underlying request.
+func (b *Bot) SendChatActionWithContext(ctx context.Context, chatId ChatID, action string, opts *SendChatActionOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["action"] = action
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
@@ -1658,7 +1731,7 @@ func (b *Bot) SendChatAction(chatId int64, action string, opts *SendChatActionOp
     }
 
 
-    r, err := b.Request("sendChatAction", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendChatAction", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1676,11 +1749,16 @@ type SetMessageReactionOpts struct {
 }
 
 // Use this method to change the chosen reactions on a message. Service messages can't be reacted to. Automatically forwarded messages from a channel to its discussion group have the same available reactions as messages in the channel. Returns True on success.
-func (b *Bot) SetMessageReaction(chatId int64, messageId int64, opts *SetMessageReactionOpts) (bool, error) {
+func (b *Bot) SetMessageReaction(chatId ChatID, messageId int64, opts *SetMessageReactionOpts) (bool, error) {
+    return b.SetMessageReactionWithContext(context.Background(), chatId, messageId, opts)
+}
+
+// SetMessageReactionWithContext is the same as SetMessageReaction, but uses the given context for the underlying request.
+func (b *Bot) SetMessageReactionWithContext(ctx context.Context, chatId ChatID, messageId int64, opts *SetMessageReactionOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["message_id"] = strconv.FormatInt(messageId, 10)
     if opts != nil {
 
@@ -1696,7 +1774,7 @@ func (b *Bot) SetMessageReaction(chatId int64, messageId int64, opts *SetMessage
     }
 
 
-    r, err := b.Request("setMessageReaction", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setMessageReaction", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1715,8 +1793,13 @@ type GetUserProfilePhotosOpts struct {
 
 // Use this method to get a list of profile pictures for a user. Returns a UserProfilePhotos object.
 func (b *Bot) GetUserProfilePhotos(userId int64, opts *GetUserProfilePhotosOpts) (*types.UserProfilePhotos, error) {
+    return b.GetUserProfilePhotosWithContext(context.Background(), userId, opts)
+}
+
+// GetUserProfilePhotosWithContext is the same as GetUserProfilePhotos, but uses the given context for the underlying request.
+func (b *Bot) GetUserProfilePhotosWithContext(ctx context.Context, userId int64, opts *GetUserProfilePhotosOpts) (*types.UserProfilePhotos, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["user_id"] = strconv.FormatInt(userId, 10)
     if opts != nil {
@@ -1725,7 +1808,7 @@ func (b *Bot) GetUserProfilePhotos(userId int64, opts *GetUserProfilePhotosOpts)
     }
 
 
-    r, err := b.Request("getUserProfilePhotos", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getUserProfilePhotos", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1739,11 +1822,16 @@ func (b *Bot) GetUserProfilePhotos(userId int64, opts *GetUserProfilePhotosOpts)
 // Use this method to get basic information about a file and prepare it for downloading. For the moment, bots can download files of up to 20MB in size. On success, a File object is returned. The file can then be downloaded via the link https://api.telegram.org/file/bot<token>/<file_path>, where <file_path> is taken from the response. It is guaranteed that the link will be valid for at least 1 hour. When the link expires, a new one can be requested by calling getFile again.
 // Note: This function may not preserve the original file name and MIME type. You should save the file's MIME type and name (if available) when the File object is received.
 func (b *Bot) GetFile(fileId string) (*types.File, error) {
+    return b.GetFileWithContext(context.Background(), fileId)
+}
+
+// GetFileWithContext is the same as GetFile, but uses the given context for the underlying request.
+func (b *Bot) GetFileWithContext(ctx context.Context, fileId string) (*types.File, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["file_id"] = fileId
 
-    r, err := b.Request("getFile", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getFile", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -1760,11 +1848,16 @@ type BanChatMemberOpts struct {
 }
 
 // Use this method to ban a user in a group, a supergroup or a channel. In the case of supergroups and channels, the user will not be able to return to the chat on their own using invite links, etc., unless unbanned first. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) BanChatMember(chatId int64, userId int64, opts *BanChatMemberOpts) (bool, error) {
+func (b *Bot) BanChatMember(chatId ChatID, userId int64, opts *BanChatMemberOpts) (bool, error) {
+    return b.BanChatMemberWithContext(context.Background(), chatId, userId, opts)
+}
+
+// BanChatMemberWithContext is the same as BanChatMember, but uses the given context for the underlying request.
+func (b *Bot) BanChatMemberWithContext(ctx context.Context, chatId ChatID, userId int64, opts *BanChatMemberOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
     if opts != nil {
         params["until_date"] = strconv.FormatInt(opts.UntilDate, 10)
@@ -1772,7 +1865,7 @@ func (b *Bot) BanChatMember(chatId int64, userId int64, opts *BanChatMemberOpts)
     }
 
 
-    r, err := b.Request("banChatMember", params, data_params)
+    r, err := b.RequestWithContext(ctx, "banChatMember", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1789,18 +1882,23 @@ type UnbanChatMemberOpts struct {
 }
 
 // Use this method to unban a previously banned user in a supergroup or channel. The user will not return to the group or channel automatically, but will be able to join via link, etc. The bot must be an administrator for this to work. By default, this method guarantees that after the call the user is not a member of the chat, but will be able to join it. So if the user is a member of the chat they will also be removed from the chat. If you don't want this, use the parameter only_if_banned. Returns True on success.
-func (b *Bot) UnbanChatMember(chatId int64, userId int64, opts *UnbanChatMemberOpts) (bool, error) {
+func (b *Bot) UnbanChatMember(chatId ChatID, userId int64, opts *UnbanChatMemberOpts) (bool, error) {
+    return b.UnbanChatMemberWithContext(context.Background(), chatId, userId, opts)
+}
+
+// UnbanChatMemberWithContext is the same as UnbanChatMember, but uses the given context for the underlying request.
+func (b *Bot) UnbanChatMemberWithContext(ctx context.Context, chatId ChatID, userId int64, opts *UnbanChatMemberOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
     if opts != nil {
         params["only_if_banned"] = strconv.FormatBool(opts.OnlyIfBanned)
     }
 
 
-    r, err := b.Request("unbanChatMember", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unbanChatMember", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1818,11 +1916,16 @@ type RestrictChatMemberOpts struct {
 }
 
 // Use this method to restrict a user in a supergroup. The bot must be an administrator in the supergroup for this to work and must have the appropriate administrator rights. Pass True for all permissions to lift restrictions from a user. Returns True on success.
-func (b *Bot) RestrictChatMember(chatId int64, userId int64, permissions *types.ChatPermissions, opts *RestrictChatMemberOpts) (bool, error) {
+func (b *Bot) RestrictChatMember(chatId ChatID, userId int64, permissions *types.ChatPermissions, opts *RestrictChatMemberOpts) (bool, error) {
+    return b.RestrictChatMemberWithContext(context.Background(), chatId, userId, permissions, opts)
+}
+
+// RestrictChatMemberWithContext is the same as RestrictChatMember, but uses the given context for the underlying request.
+func (b *Bot) RestrictChatMemberWithContext(ctx context.Context, chatId ChatID, userId int64, permissions *types.ChatPermissions, opts *RestrictChatMemberOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
 
     if permissions != nil {
@@ -1839,7 +1942,7 @@ func (b *Bot) RestrictChatMember(chatId int64, userId int64, permissions *types.
     }
 
 
-    r, err := b.Request("restrictChatMember", params, data_params)
+    r, err := b.RequestWithContext(ctx, "restrictChatMember", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1870,11 +1973,16 @@ type PromoteChatMemberOpts struct {
 }
 
 // Use this method to promote or demote a user in a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Pass False for all boolean parameters to demote a user. Returns True on success.
-func (b *Bot) PromoteChatMember(chatId int64, userId int64, opts *PromoteChatMemberOpts) (bool, error) {
+func (b *Bot) PromoteChatMember(chatId ChatID, userId int64, opts *PromoteChatMemberOpts) (bool, error) {
+    return b.PromoteChatMemberWithContext(context.Background(), chatId, userId, opts)
+}
+
+// PromoteChatMemberWithContext is the same as PromoteChatMember, but uses the given context for the underlying request.
+func (b *Bot) PromoteChatMemberWithContext(ctx context.Context, chatId ChatID, userId int64, opts *PromoteChatMemberOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
     if opts != nil {
         params["is_anonymous"] = strconv.FormatBool(opts.IsAnonymous)
@@ -1895,7 +2003,7 @@ func (b *Bot) PromoteChatMember(chatId int64, userId int64, opts *PromoteChatMem
     }
 
 
-    r, err := b.Request("promoteChatMember", params, data_params)
+    r, err := b.RequestWithContext(ctx, "promoteChatMember", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1907,14 +2015,19 @@ func (b *Bot) PromoteChatMember(chatId int64, userId int64, opts *PromoteChatMem
 }
 
 // Use this method to set a custom title for an administrator in a supergroup promoted by the bot. Returns True on success.
-func (b *Bot) SetChatAdministratorCustomTitle(chatId int64, userId int64, customTitle string) (bool, error) {
+func (b *Bot) SetChatAdministratorCustomTitle(chatId ChatID, userId int64, customTitle string) (bool, error) {
+    return b.SetChatAdministratorCustomTitleWithContext(context.Background(), chatId, userId, customTitle)
+}
+
+// SetChatAdministratorCustomTitleWithContext is the same as SetChatAdministratorCustomTitle, but uses the given context for the underlying request.
+func (b *Bot) SetChatAdministratorCustomTitleWithContext(ctx context.Context, chatId ChatID, userId int64, customTitle string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
     params["custom_title"] = customTitle
 
-    r, err := b.Request("setChatAdministratorCustomTitle", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatAdministratorCustomTitle", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1925,13 +2038,18 @@ func (b *Bot) SetChatAdministratorCustomTitle(chatId int64, userId int64, custom
 }
 
 // Use this method to ban a channel chat in a supergroup or a channel. Until the chat is unbanned, the owner of the banned chat won't be able to send messages on behalf of any of their channels. The bot must be an administrator in the supergroup or channel for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) BanChatSenderChat(chatId int64, senderChatId int64) (bool, error) {
+func (b *Bot) BanChatSenderChat(chatId ChatID, senderChatId int64) (bool, error) {
+    return b.BanChatSenderChatWithContext(context.Background(), chatId, senderChatId)
+}
+
+// BanChatSenderChatWithContext is the same as BanChatSenderChat, but uses the given context for the underlying request.
+func (b *Bot) BanChatSenderChatWithContext(ctx context.Context, chatId ChatID, senderChatId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["sender_chat_id"] = strconv.FormatInt(senderChatId, 10)
 
-    r, err := b.Request("banChatSenderChat", params, data_params)
+    r, err := b.RequestWithContext(ctx, "banChatSenderChat", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1942,13 +2060,18 @@ func (b *Bot) BanChatSenderChat(chatId int64, senderChatId int64) (bool, error)
 }
 
 // Use this method to unban a previously banned channel chat in a supergroup or channel. The bot must be an administrator for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) UnbanChatSenderChat(chatId int64, senderChatId int64) (bool, error) {
+func (b *Bot) UnbanChatSenderChat(chatId ChatID, senderChatId int64) (bool, error) {
+    return b.UnbanChatSenderChatWithContext(context.Background(), chatId, senderChatId)
+}
+
+// UnbanChatSenderChatWithContext is the same as UnbanChatSenderChat, but uses the given context for the underlying request.
+func (b *Bot) UnbanChatSenderChatWithContext(ctx context.Context, chatId ChatID, senderChatId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["sender_chat_id"] = strconv.FormatInt(senderChatId, 10)
 
-    r, err := b.Request("unbanChatSenderChat", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unbanChatSenderChat", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1964,11 +2087,16 @@ type SetChatPermissionsOpts struct {
 }
 
 // Use this method to set default chat permissions for all members. The bot must be an administrator in the group or a supergroup for this to work and must have the can_restrict_members administrator rights. Returns True on success.
-func (b *Bot) SetChatPermissions(chatId int64, permissions *types.ChatPermissions, opts *SetChatPermissionsOpts) (bool, error) {
+func (b *Bot) SetChatPermissions(chatId ChatID, permissions *types.ChatPermissions, opts *SetChatPermissionsOpts) (bool, error) {
+    return b.SetChatPermissionsWithContext(context.Background(), chatId, permissions, opts)
+}
+
+// SetChatPermissionsWithContext is the same as SetChatPermissions, but uses the given context for the underlying request.
+func (b *Bot) SetChatPermissionsWithContext(ctx context.Context, chatId ChatID, permissions *types.ChatPermissions, opts *SetChatPermissionsOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if permissions != nil {
         bs, err := json.Marshal(permissions)
@@ -1983,7 +2111,7 @@ func (b *Bot) SetChatPermissions(chatId int64, permissions *types.ChatPermission
     }
 
 
-    r, err := b.Request("setChatPermissions", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatPermissions", params, data_params)
     if err != nil {
         return false, err
     }
@@ -1995,12 +2123,17 @@ func (b *Bot) SetChatPermissions(chatId int64, permissions *types.ChatPermission
 }
 
 // Use this method to generate a new primary invite link for a chat; any previously generated primary link is revoked. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the new invite link as String on success.
-func (b *Bot) ExportChatInviteLink(chatId int64) (string, error) {
+func (b *Bot) ExportChatInviteLink(chatId ChatID) (string, error) {
+    return b.ExportChatInviteLinkWithContext(context.Background(), chatId)
+}
+
+// ExportChatInviteLinkWithContext is the same as ExportChatInviteLink, but uses the given context for the underlying request.
+func (b *Bot) ExportChatInviteLinkWithContext(ctx context.Context, chatId ChatID) (string, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("exportChatInviteLink", params, data_params)
+    r, err := b.RequestWithContext(ctx, "exportChatInviteLink", params, data_params)
     if err != nil {
         return "", err
     }
@@ -2019,11 +2152,16 @@ type CreateChatInviteLinkOpts struct {
 }
 
 // Use this method to create an additional invite link for a chat. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. The link can be revoked using the method revokeChatInviteLink. Returns the new invite link as ChatInviteLink object.
-func (b *Bot) CreateChatInviteLink(chatId int64, opts *CreateChatInviteLinkOpts) (*types.ChatInviteLink, error) {
+func (b *Bot) CreateChatInviteLink(chatId ChatID, opts *CreateChatInviteLinkOpts) (*types.ChatInviteLink, error) {
+    return b.CreateChatInviteLinkWithContext(context.Background(), chatId, opts)
+}
+
+// CreateChatInviteLinkWithContext is the same as CreateChatInviteLink, but uses the given context for the underlying request.
+func (b *Bot) CreateChatInviteLinkWithContext(ctx context.Context, chatId ChatID, opts *CreateChatInviteLinkOpts) (*types.ChatInviteLink, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     if opts != nil {
         params["name"] = opts.Name
         params["expire_date"] = strconv.FormatInt(opts.ExpireDate, 10)
@@ -2032,7 +2170,7 @@ func (b *Bot) CreateChatInviteLink(chatId int64, opts *CreateChatInviteLinkOpts)
     }
 
 
-    r, err := b.Request("createChatInviteLink", params, data_params)
+    r, err := b.RequestWithContext(ctx, "createChatInviteLink", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2052,11 +2190,16 @@ type EditChatInviteLinkOpts struct {
 }
 
 // Use this method to edit a non-primary invite link created by the bot. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the edited invite link as a ChatInviteLink object.
-func (b *Bot) EditChatInviteLink(chatId int64, inviteLink string, opts *EditChatInviteLinkOpts) (*types.ChatInviteLink, error) {
+func (b *Bot) EditChatInviteLink(chatId ChatID, inviteLink string, opts *EditChatInviteLinkOpts) (*types.ChatInviteLink, error) {
+    return b.EditChatInviteLinkWithContext(context.Background(), chatId, inviteLink, opts)
+}
+
+// EditChatInviteLinkWithContext is the same as EditChatInviteLink, but uses the given context for the underlying request.
+func (b *Bot) EditChatInviteLinkWithContext(ctx context.Context, chatId ChatID, inviteLink string, opts *EditChatInviteLinkOpts) (*types.ChatInviteLink, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["invite_link"] = inviteLink
     if opts != nil {
         params["name"] = opts.Name
@@ -2066,7 +2209,7 @@ func (b *Bot) EditChatInviteLink(chatId int64, inviteLink string, opts *EditChat
     }
 
 
-    r, err := b.Request("editChatInviteLink", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editChatInviteLink", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2078,13 +2221,18 @@ func (b *Bot) EditChatInviteLink(chatId int64, inviteLink string, opts *EditChat
 }
 
 // Use this method to revoke an invite link created by the bot. If the primary link is revoked, a new link is automatically generated. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the revoked invite link as ChatInviteLink object.
-func (b *Bot) RevokeChatInviteLink(chatId int64, inviteLink string) (*types.ChatInviteLink, error) {
+func (b *Bot) RevokeChatInviteLink(chatId ChatID, inviteLink string) (*types.ChatInviteLink, error) {
+    return b.RevokeChatInviteLinkWithContext(context.Background(), chatId, inviteLink)
+}
+
+// RevokeChatInviteLinkWithContext is the same as RevokeChatInviteLink, but uses the given context for the underlying request.
+func (b *Bot) RevokeChatInviteLinkWithContext(ctx context.Context, chatId ChatID, inviteLink string) (*types.ChatInviteLink, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["invite_link"] = inviteLink
 
-    r, err := b.Request("revokeChatInviteLink", params, data_params)
+    r, err := b.RequestWithContext(ctx, "revokeChatInviteLink", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2095,13 +2243,18 @@ func (b *Bot) RevokeChatInviteLink(chatId int64, inviteLink string) (*types.Chat
 }
 
 // Use this method to approve a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.
-func (b *Bot) ApproveChatJoinRequest(chatId int64, userId int64) (bool, error) {
+func (b *Bot) ApproveChatJoinRequest(chatId ChatID, userId int64) (bool, error) {
+    return b.ApproveChatJoinRequestWithContext(context.Background(), chatId, userId)
+}
+
+// ApproveChatJoinRequestWithContext is the same as ApproveChatJoinRequest, but uses the given context for the underlying request.
+func (b *Bot) ApproveChatJoinRequestWithContext(ctx context.Context, chatId ChatID, userId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
 
-    r, err := b.Request("approveChatJoinRequest", params, data_params)
+    r, err := b.RequestWithContext(ctx, "approveChatJoinRequest", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2112,13 +2265,18 @@ func (b *Bot) ApproveChatJoinRequest(chatId int64, userId int64) (bool, error) {
 }
 
 // Use this method to decline a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.
-func (b *Bot) DeclineChatJoinRequest(chatId int64, userId int64) (bool, error) {
+func (b *Bot) DeclineChatJoinRequest(chatId ChatID, userId int64) (bool, error) {
+    return b.DeclineChatJoinRequestWithContext(context.Background(), chatId, userId)
+}
+
+// DeclineChatJoinRequestWithContext is the same as DeclineChatJoinRequest, but uses the given context for the underlying request.
+func (b *Bot) DeclineChatJoinRequestWithContext(ctx context.Context, chatId ChatID, userId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
 
-    r, err := b.Request("declineChatJoinRequest", params, data_params)
+    r, err := b.RequestWithContext(ctx, "declineChatJoinRequest", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2129,27 +2287,26 @@ func (b *Bot) DeclineChatJoinRequest(chatId int64, userId int64) (bool, error) {
 }
 
 // Use this method to set a new profile photo for the chat. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) SetChatPhoto(chatId int64, photo types.InputFile) (bool, error) {
+func (b *Bot) SetChatPhoto(chatId ChatID, photo types.InputFile) (bool, error) {
+    return b.SetChatPhotoWithContext(context.Background(), chatId, photo)
+}
+
+// SetChatPhotoWithContext is the same as SetChatPhoto, but uses the given context for the underlying request.
+func (b *Bot) SetChatPhotoWithContext(ctx context.Context, chatId ChatID, photo types.InputFile) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
     if photo != nil {
-        switch f := photo.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["photo"] = f
-            } else {
-                params["photo"] = "attach://photo"
-                data_params["photo"] = f
-            }
-        default:
-            return false, fmt.Errorf("unknown type for InputFile: %T", photo)
+        if ref, ok := photo.Reference(); ok {
+            params["photo"] = ref
+        } else {
+            params["photo"] = "attach://photo"
+            data_params["photo"] = photo
         }
     }
 
-    r, err := b.Request("setChatPhoto", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatPhoto", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2160,12 +2317,17 @@ func (b *Bot) SetChatPhoto(chatId int64, photo types.InputFile) (bool, error) {
 }
 
 // Use this method to delete a chat photo. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) DeleteChatPhoto(chatId int64) (bool, error) {
+func (b *Bot) DeleteChatPhoto(chatId ChatID) (bool, error) {
+    return b.DeleteChatPhotoWithContext(context.Background(), chatId)
+}
+
+// DeleteChatPhotoWithContext is the same as DeleteChatPhoto, but uses the given context for the underlying request.
+func (b *Bot) DeleteChatPhotoWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("deleteChatPhoto", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteChatPhoto", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2176,13 +2338,18 @@ func (b *Bot) DeleteChatPhoto(chatId int64) (bool, error) {
 }
 
 // Use this method to change the title of a chat. Titles can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) SetChatTitle(chatId int64, title string) (bool, error) {
+func (b *Bot) SetChatTitle(chatId ChatID, title string) (bool, error) {
+    return b.SetChatTitleWithContext(context.Background(), chatId, title)
+}
+
+// SetChatTitleWithContext is the same as SetChatTitle, but uses the given context for the underlying request.
+func (b *Bot) SetChatTitleWithContext(ctx context.Context, chatId ChatID, title string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["title"] = title
 
-    r, err := b.Request("setChatTitle", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatTitle", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2198,17 +2365,22 @@ type SetChatDescriptionOpts struct {
 }
 
 // Use this method to change the description of a group, a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
-func (b *Bot) SetChatDescription(chatId int64, opts *SetChatDescriptionOpts) (bool, error) {
+func (b *Bot) SetChatDescription(chatId ChatID, opts *SetChatDescriptionOpts) (bool, error) {
+    return b.SetChatDescriptionWithContext(context.Background(), chatId, opts)
+}
+
+// SetChatDescriptionWithContext is the same as SetChatDescription, but uses the given context for the underlying request.
+func (b *Bot) SetChatDescriptionWithContext(ctx context.Context, chatId ChatID, opts *SetChatDescriptionOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     if opts != nil {
         params["description"] = opts.Description
     }
 
 
-    r, err := b.Request("setChatDescription", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatDescription", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2225,18 +2397,23 @@ type PinChatMessageOpts struct {
 }
 
 // Use this method to add a message to the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.
-func (b *Bot) PinChatMessage(chatId int64, messageId int64, opts *PinChatMessageOpts) (bool, error) {
+func (b *Bot) PinChatMessage(chatId ChatID, messageId int64, opts *PinChatMessageOpts) (bool, error) {
+    return b.PinChatMessageWithContext(context.Background(), chatId, messageId, opts)
+}
+
+// PinChatMessageWithContext is the same as PinChatMessage, but uses the given context for the underlying request.
+func (b *Bot) PinChatMessageWithContext(ctx context.Context, chatId ChatID, messageId int64, opts *PinChatMessageOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["message_id"] = strconv.FormatInt(messageId, 10)
     if opts != nil {
         params["disable_notification"] = strconv.FormatBool(opts.DisableNotification)
     }
 
 
-    r, err := b.Request("pinChatMessage", params, data_params)
+    r, err := b.RequestWithContext(ctx, "pinChatMessage", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2253,17 +2430,22 @@ type UnpinChatMessageOpts struct {
 }
 
 // Use this method to remove a message from the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.
-func (b *Bot) UnpinChatMessage(chatId int64, opts *UnpinChatMessageOpts) (bool, error) {
+func (b *Bot) UnpinChatMessage(chatId ChatID, opts *UnpinChatMessageOpts) (bool, error) {
+    return b.UnpinChatMessageWithContext(context.Background(), chatId, opts)
+}
+
+// UnpinChatMessageWithContext is the same as UnpinChatMessage, but uses the given context for the underlying request.
+func (b *Bot) UnpinChatMessageWithContext(ctx context.Context, chatId ChatID, opts *UnpinChatMessageOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     if opts != nil {
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
     }
 
 
-    r, err := b.Request("unpinChatMessage", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unpinChatMessage", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2275,12 +2457,17 @@ func (b *Bot) UnpinChatMessage(chatId int64, opts *UnpinChatMessageOpts) (bool,
 }
 
 // Use this method to clear the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.
-func (b *Bot) UnpinAllChatMessages(chatId int64) (bool, error) {
+func (b *Bot) UnpinAllChatMessages(chatId ChatID) (bool, error) {
+    return b.UnpinAllChatMessagesWithContext(context.Background(), chatId)
+}
+
+// UnpinAllChatMessagesWithContext is the same as UnpinAllChatMessages, but uses the given context for the underlying request.
+func (b *Bot) UnpinAllChatMessagesWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("unpinAllChatMessages", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unpinAllChatMessages", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2291,12 +2478,17 @@ func (b *Bot) UnpinAllChatMessages(chatId int64) (bool, error) {
 }
 
 // Use this method for your bot to leave a group, supergroup or channel. Returns True on success.
-func (b *Bot) LeaveChat(chatId int64) (bool, error) {
+func (b *Bot) LeaveChat(chatId ChatID) (bool, error) {
+    return b.LeaveChatWithContext(context.Background(), chatId)
+}
+
+// LeaveChatWithContext is the same as LeaveChat, but uses the given context for the underlying request.
+func (b *Bot) LeaveChatWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("leaveChat", params, data_params)
+    r, err := b.RequestWithContext(ctx, "leaveChat", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2307,12 +2499,17 @@ func (b *Bot) LeaveChat(chatId int64) (bool, error) {
 }
 
 // Use this method to get up-to-date information about the chat. Returns a ChatFullInfo object on success.
-func (b *Bot) GetChat(chatId int64) (*types.ChatFullInfo, error) {
+func (b *Bot) GetChat(chatId ChatID) (*types.ChatFullInfo, error) {
+    return b.GetChatWithContext(context.Background(), chatId)
+}
+
+// GetChatWithContext is the same as GetChat, but uses the given context for the underlying request.
+func (b *Bot) GetChatWithContext(ctx context.Context, chatId ChatID) (*types.ChatFullInfo, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("getChat", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getChat", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2323,12 +2520,17 @@ func (b *Bot) GetChat(chatId int64) (*types.ChatFullInfo, error) {
 }
 
 // Use this method to get a list of administrators in a chat, which aren't bots. Returns an Array of ChatMember objects.
-func (b *Bot) GetChatAdministrators(chatId int64) ([]types.ChatMember, error) {
+func (b *Bot) GetChatAdministrators(chatId ChatID) ([]types.ChatMember, error) {
+    return b.GetChatAdministratorsWithContext(context.Background(), chatId)
+}
+
+// GetChatAdministratorsWithContext is the same as GetChatAdministrators, but uses the given context for the underlying request.
+func (b *Bot) GetChatAdministratorsWithContext(ctx context.Context, chatId ChatID) ([]types.ChatMember, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("getChatAdministrators", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getChatAdministrators", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2339,12 +2541,17 @@ func (b *Bot) GetChatAdministrators(chatId int64) ([]types.ChatMember, error) {
 }
 
 // Use this method to get the number of members in a chat. Returns Int on success.
-func (b *Bot) GetChatMemberCount(chatId int64) (int64, error) {
+func (b *Bot) GetChatMemberCount(chatId ChatID) (int64, error) {
+    return b.GetChatMemberCountWithContext(context.Background(), chatId)
+}
+
+// GetChatMemberCountWithContext is the same as GetChatMemberCount, but uses the given context for the underlying request.
+func (b *Bot) GetChatMemberCountWithContext(ctx context.Context, chatId ChatID) (int64, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("getChatMemberCount", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getChatMemberCount", params, data_params)
     if err != nil {
         return 0, err
     }
@@ -2355,13 +2562,18 @@ func (b *Bot) GetChatMemberCount(chatId int64) (int64, error) {
 }
 
 // Use this method to get information about a member of a chat. The method is only guaranteed to work for other users if the bot is an administrator in the chat. Returns a ChatMember object on success.
-func (b *Bot) GetChatMember(chatId int64, userId int64) (*types.ChatMember, error) {
+func (b *Bot) GetChatMember(chatId ChatID, userId int64) (*types.ChatMember, error) {
+    return b.GetChatMemberWithContext(context.Background(), chatId, userId)
+}
+
+// GetChatMemberWithContext is the same as GetChatMember, but uses the given context for the underlying request.
+func (b *Bot) GetChatMemberWithContext(ctx context.Context, chatId ChatID, userId int64) (*types.ChatMember, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
 
-    r, err := b.Request("getChatMember", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getChatMember", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2372,13 +2584,18 @@ func (b *Bot) GetChatMember(chatId int64, userId int64) (*types.ChatMember, erro
 }
 
 // Use this method to set a new group sticker set for a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method. Returns True on success.
-func (b *Bot) SetChatStickerSet(chatId int64, stickerSetName string) (bool, error) {
+func (b *Bot) SetChatStickerSet(chatId ChatID, stickerSetName string) (bool, error) {
+    return b.SetChatStickerSetWithContext(context.Background(), chatId, stickerSetName)
+}
+
+// SetChatStickerSetWithContext is the same as SetChatStickerSet, but uses the given context for the underlying request.
+func (b *Bot) SetChatStickerSetWithContext(ctx context.Context, chatId ChatID, stickerSetName string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["sticker_set_name"] = stickerSetName
 
-    r, err := b.Request("setChatStickerSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatStickerSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2389,12 +2606,17 @@ func (b *Bot) SetChatStickerSet(chatId int64, stickerSetName string) (bool, erro
 }
 
 // Use this method to delete a group sticker set from a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method. Returns True on success.
-func (b *Bot) DeleteChatStickerSet(chatId int64) (bool, error) {
+func (b *Bot) DeleteChatStickerSet(chatId ChatID) (bool, error) {
+    return b.DeleteChatStickerSetWithContext(context.Background(), chatId)
+}
+
+// DeleteChatStickerSetWithContext is the same as DeleteChatStickerSet, but uses the given context for the underlying request.
+func (b *Bot) DeleteChatStickerSetWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("deleteChatStickerSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteChatStickerSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2406,10 +2628,15 @@ func (b *Bot) DeleteChatStickerSet(chatId int64) (bool, error) {
 
 // Use this method to get custom emoji stickers, which can be used as a forum topic icon by any user. Requires no parameters. Returns an Array of Sticker objects.
 func (b *Bot) GetForumTopicIconStickers() ([]types.Sticker, error) {
+    return b.GetForumTopicIconStickersWithContext(context.Background())
+}
+
+// GetForumTopicIconStickersWithContext is the same as GetForumTopicIconStickers, but uses the given context for the underlying request.
+func (b *Bot) GetForumTopicIconStickersWithContext(ctx context.Context) ([]types.Sticker, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    r, err := b.Request("getForumTopicIconStickers", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getForumTopicIconStickers", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2426,11 +2653,16 @@ type CreateForumTopicOpts struct {
 }
 
 // Use this method to create a topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns information about the created topic as a ForumTopic object.
-func (b *Bot) CreateForumTopic(chatId int64, name string, opts *CreateForumTopicOpts) (*types.ForumTopic, error) {
+func (b *Bot) CreateForumTopic(chatId ChatID, name string, opts *CreateForumTopicOpts) (*types.ForumTopic, error) {
+    return b.CreateForumTopicWithContext(context.Background(), chatId, name, opts)
+}
+
+// CreateForumTopicWithContext is the same as CreateForumTopic, but uses the given context for the underlying request.
+func (b *Bot) CreateForumTopicWithContext(ctx context.Context, chatId ChatID, name string, opts *CreateForumTopicOpts) (*types.ForumTopic, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["name"] = name
     if opts != nil {
         params["icon_color"] = strconv.FormatInt(opts.IconColor, 10)
@@ -2438,7 +2670,7 @@ func (b *Bot) CreateForumTopic(chatId int64, name string, opts *CreateForumTopic
     }
 
 
-    r, err := b.Request("createForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "createForumTopic", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2456,11 +2688,16 @@ type EditForumTopicOpts struct {
 }
 
 // Use this method to edit name and icon of a topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have can_manage_topics administrator rights, unless it is the creator of the topic. Returns True on success.
-func (b *Bot) EditForumTopic(chatId int64, messageThreadId int64, opts *EditForumTopicOpts) (bool, error) {
+func (b *Bot) EditForumTopic(chatId ChatID, messageThreadId int64, opts *EditForumTopicOpts) (bool, error) {
+    return b.EditForumTopicWithContext(context.Background(), chatId, messageThreadId, opts)
+}
+
+// EditForumTopicWithContext is the same as EditForumTopic, but uses the given context for the underlying request.
+func (b *Bot) EditForumTopicWithContext(ctx context.Context, chatId ChatID, messageThreadId int64, opts *EditForumTopicOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["message_thread_id"] = strconv.FormatInt(messageThreadId, 10)
     if opts != nil {
         params["name"] = opts.Name
@@ -2468,7 +2705,7 @@ func (b *Bot) EditForumTopic(chatId int64, messageThreadId int64, opts *EditForu
     }
 
 
-    r, err := b.Request("editForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2480,13 +2717,18 @@ func (b *Bot) EditForumTopic(chatId int64, messageThreadId int64, opts *EditForu
 }
 
 // Use this method to close an open topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic. Returns True on success.
-func (b *Bot) CloseForumTopic(chatId int64, messageThreadId int64) (bool, error) {
+func (b *Bot) CloseForumTopic(chatId ChatID, messageThreadId int64) (bool, error) {
+    return b.CloseForumTopicWithContext(context.Background(), chatId, messageThreadId)
+}
+
+// CloseForumTopicWithContext is the same as CloseForumTopic, but uses the given context for the underlying request.
+func (b *Bot) CloseForumTopicWithContext(ctx context.Context, chatId ChatID, messageThreadId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["message_thread_id"] = strconv.FormatInt(messageThreadId, 10)
 
-    r, err := b.Request("closeForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "closeForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2497,13 +2739,18 @@ func (b *Bot) CloseForumTopic(chatId int64, messageThreadId int64) (bool, error)
 }
 
 // Use this method to reopen a closed topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic. Returns True on success.
-func (b *Bot) ReopenForumTopic(chatId int64, messageThreadId int64) (bool, error) {
+func (b *Bot) ReopenForumTopic(chatId ChatID, messageThreadId int64) (bool, error) {
+    return b.ReopenForumTopicWithContext(context.Background(), chatId, messageThreadId)
+}
+
+// ReopenForumTopicWithContext is the same as ReopenForumTopic, but uses the given context for the underlying request.
+func (b *Bot) ReopenForumTopicWithContext(ctx context.Context, chatId ChatID, messageThreadId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["message_thread_id"] = strconv.FormatInt(messageThreadId, 10)
 
-    r, err := b.Request("reopenForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "reopenForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2514,13 +2761,18 @@ func (b *Bot) ReopenForumTopic(chatId int64, messageThreadId int64) (bool, error
 }
 
 // Use this method to delete a forum topic along with all its messages in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_delete_messages administrator rights. Returns True on success.
-func (b *Bot) DeleteForumTopic(chatId int64, messageThreadId int64) (bool, error) {
+func (b *Bot) DeleteForumTopic(chatId ChatID, messageThreadId int64) (bool, error) {
+    return b.DeleteForumTopicWithContext(context.Background(), chatId, messageThreadId)
+}
+
+// DeleteForumTopicWithContext is the same as DeleteForumTopic, but uses the given context for the underlying request.
+func (b *Bot) DeleteForumTopicWithContext(ctx context.Context, chatId ChatID, messageThreadId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["message_thread_id"] = strconv.FormatInt(messageThreadId, 10)
 
-    r, err := b.Request("deleteForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2531,13 +2783,18 @@ func (b *Bot) DeleteForumTopic(chatId int64, messageThreadId int64) (bool, error
 }
 
 // Use this method to clear the list of pinned messages in a forum topic. The bot must be an administrator in the chat for this to work and must have the can_pin_messages administrator right in the supergroup. Returns True on success.
-func (b *Bot) UnpinAllForumTopicMessages(chatId int64, messageThreadId int64) (bool, error) {
+func (b *Bot) UnpinAllForumTopicMessages(chatId ChatID, messageThreadId int64) (bool, error) {
+    return b.UnpinAllForumTopicMessagesWithContext(context.Background(), chatId, messageThreadId)
+}
+
+// UnpinAllForumTopicMessagesWithContext is the same as UnpinAllForumTopicMessages, but uses the given context for the underlying request.
+func (b *Bot) UnpinAllForumTopicMessagesWithContext(ctx context.Context, chatId ChatID, messageThreadId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["message_thread_id"] = strconv.FormatInt(messageThreadId, 10)
 
-    r, err := b.Request("unpinAllForumTopicMessages", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unpinAllForumTopicMessages", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2548,13 +2805,18 @@ func (b *Bot) UnpinAllForumTopicMessages(chatId int64, messageThreadId int64) (b
 }
 
 // Use this method to edit the name of the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have can_manage_topics administrator rights. Returns True on success.
-func (b *Bot) EditGeneralForumTopic(chatId int64, name string) (bool, error) {
+func (b *Bot) EditGeneralForumTopic(chatId ChatID, name string) (bool, error) {
+    return b.EditGeneralForumTopicWithContext(context.Background(), chatId, name)
+}
+
+// EditGeneralForumTopicWithContext is the same as EditGeneralForumTopic, but uses the given context for the underlying request.
+func (b *Bot) EditGeneralForumTopicWithContext(ctx context.Context, chatId ChatID, name string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["name"] = name
 
-    r, err := b.Request("editGeneralForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editGeneralForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2565,12 +2827,17 @@ func (b *Bot) EditGeneralForumTopic(chatId int64, name string) (bool, error) {
 }
 
 // Use this method to close an open 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns True on success.
-func (b *Bot) CloseGeneralForumTopic(chatId int64) (bool, error) {
+func (b *Bot) CloseGeneralForumTopic(chatId ChatID) (bool, error) {
+    return b.CloseGeneralForumTopicWithContext(context.Background(), chatId)
+}
+
+// CloseGeneralForumTopicWithContext is the same as CloseGeneralForumTopic, but uses the given context for the underlying request.
+func (b *Bot) CloseGeneralForumTopicWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("closeGeneralForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "closeGeneralForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2581,12 +2848,17 @@ func (b *Bot) CloseGeneralForumTopic(chatId int64) (bool, error) {
 }
 
 // Use this method to reopen a closed 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. The topic will be automatically unhidden if it was hidden. Returns True on success.
-func (b *Bot) ReopenGeneralForumTopic(chatId int64) (bool, error) {
+func (b *Bot) ReopenGeneralForumTopic(chatId ChatID) (bool, error) {
+    return b.ReopenGeneralForumTopicWithContext(context.Background(), chatId)
+}
+
+// ReopenGeneralForumTopicWithContext is the same as ReopenGeneralForumTopic, but uses the given context for the underlying request.
+func (b *Bot) ReopenGeneralForumTopicWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("reopenGeneralForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "reopenGeneralForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2597,12 +2869,17 @@ func (b *Bot) ReopenGeneralForumTopic(chatId int64) (bool, error) {
 }
 
 // Use this method to hide the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. The topic will be automatically closed if it was open. Returns True on success.
-func (b *Bot) HideGeneralForumTopic(chatId int64) (bool, error) {
+func (b *Bot) HideGeneralForumTopic(chatId ChatID) (bool, error) {
+    return b.HideGeneralForumTopicWithContext(context.Background(), chatId)
+}
+
+// HideGeneralForumTopicWithContext is the same as HideGeneralForumTopic, but uses the given context for the underlying request.
+func (b *Bot) HideGeneralForumTopicWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("hideGeneralForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "hideGeneralForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2613,12 +2890,17 @@ func (b *Bot) HideGeneralForumTopic(chatId int64) (bool, error) {
 }
 
 // Use this method to unhide the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns True on success.
-func (b *Bot) UnhideGeneralForumTopic(chatId int64) (bool, error) {
+func (b *Bot) UnhideGeneralForumTopic(chatId ChatID) (bool, error) {
+    return b.UnhideGeneralForumTopicWithContext(context.Background(), chatId)
+}
+
+// UnhideGeneralForumTopicWithContext is the same as UnhideGeneralForumTopic, but uses the given context for the underlying request.
+func (b *Bot) UnhideGeneralForumTopicWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("unhideGeneralForumTopic", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unhideGeneralForumTopic", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2629,12 +2911,17 @@ func (b *Bot) UnhideGeneralForumTopic(chatId int64) (bool, error) {
 }
 
 // Use this method to clear the list of pinned messages in a General forum topic. The bot must be an administrator in the chat for this to work and must have the can_pin_messages administrator right in the supergroup. Returns True on success.
-func (b *Bot) UnpinAllGeneralForumTopicMessages(chatId int64) (bool, error) {
+func (b *Bot) UnpinAllGeneralForumTopicMessages(chatId ChatID) (bool, error) {
+    return b.UnpinAllGeneralForumTopicMessagesWithContext(context.Background(), chatId)
+}
+
+// UnpinAllGeneralForumTopicMessagesWithContext is the same as UnpinAllGeneralForumTopicMessages, but uses the given context for the underlying request.
+func (b *Bot) UnpinAllGeneralForumTopicMessagesWithContext(ctx context.Context, chatId ChatID) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
-    r, err := b.Request("unpinAllGeneralForumTopicMessages", params, data_params)
+    r, err := b.RequestWithContext(ctx, "unpinAllGeneralForumTopicMessages", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2654,8 +2941,13 @@ type AnswerCallbackQueryOpts struct {
 
 // Use this method to send answers to callback queries sent from inline keyboards. The answer will be displayed to the user as a notification at the top of the chat screen or as an alert. On success, True is returned.
 func (b *Bot) AnswerCallbackQuery(callbackQueryId string, opts *AnswerCallbackQueryOpts) (bool, error) {
+    return b.AnswerCallbackQueryWithContext(context.Background(), callbackQueryId, opts)
+}
+
+// AnswerCallbackQueryWithContext is the same as AnswerCallbackQuery, but uses the given context for the underlying request.
+func (b *Bot) AnswerCallbackQueryWithContext(ctx context.Context, callbackQueryId string, opts *AnswerCallbackQueryOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["callback_query_id"] = callbackQueryId
     if opts != nil {
@@ -2666,7 +2958,7 @@ func (b *Bot) AnswerCallbackQuery(callbackQueryId string, opts *AnswerCallbackQu
     }
 
 
-    r, err := b.Request("answerCallbackQuery", params, data_params)
+    r, err := b.RequestWithContext(ctx, "answerCallbackQuery", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2678,13 +2970,18 @@ func (b *Bot) AnswerCallbackQuery(callbackQueryId string, opts *AnswerCallbackQu
 }
 
 // Use this method to get the list of boosts added to a chat by a user. Requires administrator rights in the chat. Returns a UserChatBoosts object.
-func (b *Bot) GetUserChatBoosts(chatId int64, userId int64) (*types.UserChatBoosts, error) {
+func (b *Bot) GetUserChatBoosts(chatId ChatID, userId int64) (*types.UserChatBoosts, error) {
+    return b.GetUserChatBoostsWithContext(context.Background(), chatId, userId)
+}
+
+// GetUserChatBoostsWithContext is the same as GetUserChatBoosts, but uses the given context for the underlying request.
+func (b *Bot) GetUserChatBoostsWithContext(ctx context.Context, chatId ChatID, userId int64) (*types.UserChatBoosts, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["user_id"] = strconv.FormatInt(userId, 10)
 
-    r, err := b.Request("getUserChatBoosts", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getUserChatBoosts", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2696,11 +2993,16 @@ func (b *Bot) GetUserChatBoosts(chatId int64, userId int64) (*types.UserChatBoos
 
 // Use this method to get information about the connection of the bot with a business account. Returns a BusinessConnection object on success.
 func (b *Bot) GetBusinessConnection(businessConnectionId string) (*types.BusinessConnection, error) {
+    return b.GetBusinessConnectionWithContext(context.Background(), businessConnectionId)
+}
+
+// GetBusinessConnectionWithContext is the same as GetBusinessConnection, but uses the given context for the underlying request.
+func (b *Bot) GetBusinessConnectionWithContext(ctx context.Context, businessConnectionId string) (*types.BusinessConnection, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["business_connection_id"] = businessConnectionId
 
-    r, err := b.Request("getBusinessConnection", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getBusinessConnection", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2718,8 +3020,13 @@ type SetMyCommandsOpts struct {
 
 // Use this method to change the list of the bot's commands. See this manual for more details about bot commands. Returns True on success.
 func (b *Bot) SetMyCommands(commands []types.BotCommand, opts *SetMyCommandsOpts) (bool, error) {
+    return b.SetMyCommandsWithContext(context.Background(), commands, opts)
+}
+
+// SetMyCommandsWithContext is the same as SetMyCommands, but uses the given context for the underlying request.
+func (b *Bot) SetMyCommandsWithContext(ctx context.Context, commands []types.BotCommand, opts *SetMyCommandsOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
 
     if commands != nil {
@@ -2744,7 +3051,7 @@ func (b *Bot) SetMyCommands(commands []types.BotCommand, opts *SetMyCommandsOpts
     }
 
 
-    r, err := b.Request("setMyCommands", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setMyCommands", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2763,8 +3070,13 @@ type DeleteMyCommandsOpts struct {
 
 // Use this method to delete the list of the bot's commands for the given scope and user language. After deletion, higher level commands will be shown to affected users. Returns True on success.
 func (b *Bot) DeleteMyCommands(opts *DeleteMyCommandsOpts) (bool, error) {
+    return b.DeleteMyCommandsWithContext(context.Background(), opts)
+}
+
+// DeleteMyCommandsWithContext is the same as DeleteMyCommands, but uses the given context for the underlying request.
+func (b *Bot) DeleteMyCommandsWithContext(ctx context.Context, opts *DeleteMyCommandsOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
 
@@ -2780,7 +3092,7 @@ func (b *Bot) DeleteMyCommands(opts *DeleteMyCommandsOpts) (bool, error) {
     }
 
 
-    r, err := b.Request("deleteMyCommands", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteMyCommands", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2799,8 +3111,13 @@ type GetMyCommandsOpts struct {
 
 // Use this method to get the current list of the bot's commands for the given scope and user language. Returns an Array of BotCommand objects. If commands aren't set, an empty list is returned.
 func (b *Bot) GetMyCommands(opts *GetMyCommandsOpts) ([]types.BotCommand, error) {
+    return b.GetMyCommandsWithContext(context.Background(), opts)
+}
+
+// GetMyCommandsWithContext is the same as GetMyCommands, but uses the given context for the underlying request.
+func (b *Bot) GetMyCommandsWithContext(ctx context.Context, opts *GetMyCommandsOpts) ([]types.BotCommand, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
 
@@ -2816,7 +3133,7 @@ func (b *Bot) GetMyCommands(opts *GetMyCommandsOpts) ([]types.BotCommand, error)
     }
 
 
-    r, err := b.Request("getMyCommands", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getMyCommands", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2835,8 +3152,13 @@ type SetMyNameOpts struct {
 
 // Use this method to change the bot's name. Returns True on success.
 func (b *Bot) SetMyName(opts *SetMyNameOpts) (bool, error) {
+    return b.SetMyNameWithContext(context.Background(), opts)
+}
+
+// SetMyNameWithContext is the same as SetMyName, but uses the given context for the underlying request.
+func (b *Bot) SetMyNameWithContext(ctx context.Context, opts *SetMyNameOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["name"] = opts.Name
@@ -2844,7 +3166,7 @@ func (b *Bot) SetMyName(opts *SetMyNameOpts) (bool, error) {
     }
 
 
-    r, err := b.Request("setMyName", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setMyName", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2862,15 +3184,20 @@ type GetMyNameOpts struct {
 
 // Use this method to get the current bot name for the given user language. Returns BotName on success.
 func (b *Bot) GetMyName(opts *GetMyNameOpts) (*types.BotName, error) {
+    return b.GetMyNameWithContext(context.Background(), opts)
+}
+
+// GetMyNameWithContext is the same as GetMyName, but uses the given context for the underlying request.
+func (b *Bot) GetMyNameWithContext(ctx context.Context, opts *GetMyNameOpts) (*types.BotName, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["language_code"] = opts.LanguageCode
     }
 
 
-    r, err := b.Request("getMyName", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getMyName", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2889,8 +3216,13 @@ type SetMyDescriptionOpts struct {
 
 // Use this method to change the bot's description, which is shown in the chat with the bot if the chat is empty. Returns True on success.
 func (b *Bot) SetMyDescription(opts *SetMyDescriptionOpts) (bool, error) {
+    return b.SetMyDescriptionWithContext(context.Background(), opts)
+}
+
+// SetMyDescriptionWithContext is the same as SetMyDescription, but uses the given context for the underlying request.
+func (b *Bot) SetMyDescriptionWithContext(ctx context.Context, opts *SetMyDescriptionOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["description"] = opts.Description
@@ -2898,7 +3230,7 @@ func (b *Bot) SetMyDescription(opts *SetMyDescriptionOpts) (bool, error) {
     }
 
 
-    r, err := b.Request("setMyDescription", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setMyDescription", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2916,15 +3248,20 @@ type GetMyDescriptionOpts struct {
 
 // Use this method to get the current bot description for the given user language. Returns BotDescription on success.
 func (b *Bot) GetMyDescription(opts *GetMyDescriptionOpts) (*types.BotDescription, error) {
+    return b.GetMyDescriptionWithContext(context.Background(), opts)
+}
+
+// GetMyDescriptionWithContext is the same as GetMyDescription, but uses the given context for the underlying request.
+func (b *Bot) GetMyDescriptionWithContext(ctx context.Context, opts *GetMyDescriptionOpts) (*types.BotDescription, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["language_code"] = opts.LanguageCode
     }
 
 
-    r, err := b.Request("getMyDescription", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getMyDescription", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2943,8 +3280,13 @@ type SetMyShortDescriptionOpts struct {
 
 // Use this method to change the bot's short description, which is shown on the bot's profile page and is sent together with the link when users share the bot. Returns True on success.
 func (b *Bot) SetMyShortDescription(opts *SetMyShortDescriptionOpts) (bool, error) {
+    return b.SetMyShortDescriptionWithContext(context.Background(), opts)
+}
+
+// SetMyShortDescriptionWithContext is the same as SetMyShortDescription, but uses the given context for the underlying request.
+func (b *Bot) SetMyShortDescriptionWithContext(ctx context.Context, opts *SetMyShortDescriptionOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["short_description"] = opts.ShortDescription
@@ -2952,7 +3294,7 @@ func (b *Bot) SetMyShortDescription(opts *SetMyShortDescriptionOpts) (bool, erro
     }
 
 
-    r, err := b.Request("setMyShortDescription", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setMyShortDescription", params, data_params)
     if err != nil {
         return false, err
     }
@@ -2970,15 +3312,20 @@ type GetMyShortDescriptionOpts struct {
 
 // Use this method to get the current bot short description for the given user language. Returns BotShortDescription on success.
 func (b *Bot) GetMyShortDescription(opts *GetMyShortDescriptionOpts) (*types.BotShortDescription, error) {
+    return b.GetMyShortDescriptionWithContext(context.Background(), opts)
+}
+
+// GetMyShortDescriptionWithContext is the same as GetMyShortDescription, but uses the given context for the underlying request.
+func (b *Bot) GetMyShortDescriptionWithContext(ctx context.Context, opts *GetMyShortDescriptionOpts) (*types.BotShortDescription, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["language_code"] = opts.LanguageCode
     }
 
 
-    r, err := b.Request("getMyShortDescription", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getMyShortDescription", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -2997,8 +3344,13 @@ type SetChatMenuButtonOpts struct {
 
 // Use this method to change the bot's menu button in a private chat, or the default menu button. Returns True on success.
 func (b *Bot) SetChatMenuButton(opts *SetChatMenuButtonOpts) (bool, error) {
+    return b.SetChatMenuButtonWithContext(context.Background(), opts)
+}
+
+// SetChatMenuButtonWithContext is the same as SetChatMenuButton, but uses the given context for the underlying request.
+func (b *Bot) SetChatMenuButtonWithContext(ctx context.Context, opts *SetChatMenuButtonOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
@@ -3014,7 +3366,7 @@ func (b *Bot) SetChatMenuButton(opts *SetChatMenuButtonOpts) (bool, error) {
     }
 
 
-    r, err := b.Request("setChatMenuButton", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setChatMenuButton", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3032,15 +3384,20 @@ type GetChatMenuButtonOpts struct {
 
 // Use this method to get the current value of the bot's menu button in a private chat, or the default menu button. Returns MenuButton on success.
 func (b *Bot) GetChatMenuButton(opts *GetChatMenuButtonOpts) (*types.MenuButton, error) {
+    return b.GetChatMenuButtonWithContext(context.Background(), opts)
+}
+
+// GetChatMenuButtonWithContext is the same as GetChatMenuButton, but uses the given context for the underlying request.
+func (b *Bot) GetChatMenuButtonWithContext(ctx context.Context, opts *GetChatMenuButtonOpts) (*types.MenuButton, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
     }
 
 
-    r, err := b.Request("getChatMenuButton", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getChatMenuButton", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3059,8 +3416,13 @@ type SetMyDefaultAdministratorRightsOpts struct {
 
 // Use this method to change the default administrator rights requested by the bot when it's added as an administrator to groups or channels. These rights will be suggested to users, but they are free to modify the list before adding the bot. Returns True on success.
 func (b *Bot) SetMyDefaultAdministratorRights(opts *SetMyDefaultAdministratorRightsOpts) (bool, error) {
+    return b.SetMyDefaultAdministratorRightsWithContext(context.Background(), opts)
+}
+
+// SetMyDefaultAdministratorRightsWithContext is the same as SetMyDefaultAdministratorRights, but uses the given context for the underlying request.
+func (b *Bot) SetMyDefaultAdministratorRightsWithContext(ctx context.Context, opts *SetMyDefaultAdministratorRightsOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
 
@@ -3076,7 +3438,7 @@ func (b *Bot) SetMyDefaultAdministratorRights(opts *SetMyDefaultAdministratorRig
     }
 
 
-    r, err := b.Request("setMyDefaultAdministratorRights", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setMyDefaultAdministratorRights", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3094,15 +3456,20 @@ type GetMyDefaultAdministratorRightsOpts struct {
 
 // Use this method to get the current default administrator rights of the bot. Returns ChatAdministratorRights on success.
 func (b *Bot) GetMyDefaultAdministratorRights(opts *GetMyDefaultAdministratorRightsOpts) (*types.ChatAdministratorRights, error) {
+    return b.GetMyDefaultAdministratorRightsWithContext(context.Background(), opts)
+}
+
+// GetMyDefaultAdministratorRightsWithContext is the same as GetMyDefaultAdministratorRights, but uses the given context for the underlying request.
+func (b *Bot) GetMyDefaultAdministratorRightsWithContext(ctx context.Context, opts *GetMyDefaultAdministratorRightsOpts) (*types.ChatAdministratorRights, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["for_channels"] = strconv.FormatBool(opts.ForChannels)
     }
 
 
-    r, err := b.Request("getMyDefaultAdministratorRights", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getMyDefaultAdministratorRights", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3116,7 +3483,7 @@ func (b *Bot) GetMyDefaultAdministratorRights(opts *GetMyDefaultAdministratorRig
 // EditMessageText methods's optional params
 type EditMessageTextOpts struct {
     BusinessConnectionId string `json:"business_connection_id,omitempty"`
-    ChatId int64 `json:"chat_id,omitempty"`
+    ChatId ChatID `json:"chat_id,omitempty"`
     MessageId int64 `json:"message_id,omitempty"`
     InlineMessageId string `json:"inline_message_id,omitempty"`
     ParseMode string `json:"parse_mode,omitempty"`
@@ -3127,13 +3494,18 @@ type EditMessageTextOpts struct {
 
 // Use this method to edit text and game messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 func (b *Bot) EditMessageText(text string, opts *EditMessageTextOpts) (*types.Message, error) {
+    return b.EditMessageTextWithContext(context.Background(), text, opts)
+}
+
+// EditMessageTextWithContext is the same as EditMessageText, but uses the given context for the underlying request.
+func (b *Bot) EditMessageTextWithContext(ctx context.Context, text string, opts *EditMessageTextOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["text"] = text
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
-        params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
+        params["chat_id"] = opts.ChatId.String()
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
         params["inline_message_id"] = opts.InlineMessageId
         params["parse_mode"] = opts.ParseMode
@@ -3167,7 +3539,7 @@ func (b *Bot) EditMessageText(text string, opts *EditMessageTextOpts) (*types.Me
     }
 
 
-    r, err := b.Request("editMessageText", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editMessageText", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3181,7 +3553,7 @@ func (b *Bot) EditMessageText(text string, opts *EditMessageTextOpts) (*types.Me
 // EditMessageCaption methods's optional params
 type EditMessageCaptionOpts struct {
     BusinessConnectionId string `json:"business_connection_id,omitempty"`
-    ChatId int64 `json:"chat_id,omitempty"`
+    ChatId ChatID `json:"chat_id,omitempty"`
     MessageId int64 `json:"message_id,omitempty"`
     InlineMessageId string `json:"inline_message_id,omitempty"`
     Caption string `json:"caption,omitempty"`
@@ -3193,12 +3565,17 @@ type EditMessageCaptionOpts struct {
 
 // Use this method to edit captions of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 func (b *Bot) EditMessageCaption(opts *EditMessageCaptionOpts) (*types.Message, error) {
+    return b.EditMessageCaptionWithContext(context.Background(), opts)
+}
+
+// EditMessageCaptionWithContext is the same as EditMessageCaption, but uses the given context for the underlying request.
+func (b *Bot) EditMessageCaptionWithContext(ctx context.Context, opts *EditMessageCaptionOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
-        params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
+        params["chat_id"] = opts.ChatId.String()
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
         params["inline_message_id"] = opts.InlineMessageId
         params["caption"] = opts.Caption
@@ -3225,7 +3602,7 @@ func (b *Bot) EditMessageCaption(opts *EditMessageCaptionOpts) (*types.Message,
     }
 
 
-    r, err := b.Request("editMessageCaption", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editMessageCaption", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3239,7 +3616,7 @@ func (b *Bot) EditMessageCaption(opts *EditMessageCaptionOpts) (*types.Message,
 // EditMessageMedia methods's optional params
 type EditMessageMediaOpts struct {
     BusinessConnectionId string `json:"business_connection_id,omitempty"`
-    ChatId int64 `json:"chat_id,omitempty"`
+    ChatId ChatID `json:"chat_id,omitempty"`
     MessageId int64 `json:"message_id,omitempty"`
     InlineMessageId string `json:"inline_message_id,omitempty"`
     ReplyMarkup *types.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
@@ -3247,12 +3624,17 @@ type EditMessageMediaOpts struct {
 
 // Use this method to edit animation, audio, document, photo, or video messages. If a message is part of a message album, then it can be edited only to an audio for audio albums, only to a document for document albums and to a photo or a video otherwise. When an inline message is edited, a new file can't be uploaded; use a previously uploaded file via its file_id or specify a URL. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 func (b *Bot) EditMessageMedia(media *types.InputMedia, opts *EditMessageMediaOpts) (*types.Message, error) {
+    return b.EditMessageMediaWithContext(context.Background(), media, opts)
+}
+
+// EditMessageMediaWithContext is the same as EditMessageMedia, but uses the given context for the underlying request.
+func (b *Bot) EditMessageMediaWithContext(ctx context.Context, media *types.InputMedia, opts *EditMessageMediaOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
 
     if media != nil {
-        bs, err := json.Marshal(media)
+        bs, err := media.InputParams("media", data_params)
         if err != nil {
             return nil, fmt.Errorf("failed to marshal field media: %w", err)
         }
@@ -3261,7 +3643,7 @@ func (b *Bot) EditMessageMedia(media *types.InputMedia, opts *EditMessageMediaOp
 
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
-        params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
+        params["chat_id"] = opts.ChatId.String()
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
         params["inline_message_id"] = opts.InlineMessageId
 
@@ -3276,7 +3658,7 @@ func (b *Bot) EditMessageMedia(media *types.InputMedia, opts *EditMessageMediaOp
     }
 
 
-    r, err := b.Request("editMessageMedia", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editMessageMedia", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3290,7 +3672,7 @@ func (b *Bot) EditMessageMedia(media *types.InputMedia, opts *EditMessageMediaOp
 // EditMessageLiveLocation methods's optional params
 type EditMessageLiveLocationOpts struct {
     BusinessConnectionId string `json:"business_connection_id,omitempty"`
-    ChatId int64 `json:"chat_id,omitempty"`
+    ChatId ChatID `json:"chat_id,omitempty"`
     MessageId int64 `json:"message_id,omitempty"`
     InlineMessageId string `json:"inline_message_id,omitempty"`
     LivePeriod int64 `json:"live_period,omitempty"`
@@ -3302,14 +3684,19 @@ type EditMessageLiveLocationOpts struct {
 
 // Use this method to edit live location messages. A location can be edited until its live_period expires or editing is explicitly disabled by a call to stopMessageLiveLocation. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.
 func (b *Bot) EditMessageLiveLocation(latitude float64, longitude float64, opts *EditMessageLiveLocationOpts) (*types.Message, error) {
+    return b.EditMessageLiveLocationWithContext(context.Background(), latitude, longitude, opts)
+}
+
+// EditMessageLiveLocationWithContext is the same as EditMessageLiveLocation, but uses the given context for the underlying request.
+func (b *Bot) EditMessageLiveLocationWithContext(ctx context.Context, latitude float64, longitude float64, opts *EditMessageLiveLocationOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["latitude"] = strconv.FormatFloat(latitude, 'E', -1, 64)
     params["longitude"] = strconv.FormatFloat(longitude, 'E', -1, 64)
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
-        params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
+        params["chat_id"] = opts.ChatId.String()
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
         params["inline_message_id"] = opts.InlineMessageId
         params["live_period"] = strconv.FormatInt(opts.LivePeriod, 10)
@@ -3328,7 +3715,7 @@ func (b *Bot) EditMessageLiveLocation(latitude float64, longitude float64, opts
     }
 
 
-    r, err := b.Request("editMessageLiveLocation", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editMessageLiveLocation", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3342,7 +3729,7 @@ func (b *Bot) EditMessageLiveLocation(latitude float64, longitude float64, opts
 // StopMessageLiveLocation methods's optional params
 type StopMessageLiveLocationOpts struct {
     BusinessConnectionId string `json:"business_connection_id,omitempty"`
-    ChatId int64 `json:"chat_id,omitempty"`
+    ChatId ChatID `json:"chat_id,omitempty"`
     MessageId int64 `json:"message_id,omitempty"`
     InlineMessageId string `json:"inline_message_id,omitempty"`
     ReplyMarkup *types.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
@@ -3350,12 +3737,17 @@ type StopMessageLiveLocationOpts struct {
 
 // Use this method to stop updating a live location message before live_period expires. On success, if the message is not an inline message, the edited Message is returned, otherwise True is returned.
 func (b *Bot) StopMessageLiveLocation(opts *StopMessageLiveLocationOpts) (*types.Message, error) {
+    return b.StopMessageLiveLocationWithContext(context.Background(), opts)
+}
+
+// StopMessageLiveLocationWithContext is the same as StopMessageLiveLocation, but uses the given context for the underlying request.
+func (b *Bot) StopMessageLiveLocationWithContext(ctx context.Context, opts *StopMessageLiveLocationOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
-        params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
+        params["chat_id"] = opts.ChatId.String()
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
         params["inline_message_id"] = opts.InlineMessageId
 
@@ -3370,7 +3762,7 @@ func (b *Bot) StopMessageLiveLocation(opts *StopMessageLiveLocationOpts) (*types
     }
 
 
-    r, err := b.Request("stopMessageLiveLocation", params, data_params)
+    r, err := b.RequestWithContext(ctx, "stopMessageLiveLocation", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3384,7 +3776,7 @@ func (b *Bot) StopMessageLiveLocation(opts *StopMessageLiveLocationOpts) (*types
 // EditMessageReplyMarkup methods's optional params
 type EditMessageReplyMarkupOpts struct {
     BusinessConnectionId string `json:"business_connection_id,omitempty"`
-    ChatId int64 `json:"chat_id,omitempty"`
+    ChatId ChatID `json:"chat_id,omitempty"`
     MessageId int64 `json:"message_id,omitempty"`
     InlineMessageId string `json:"inline_message_id,omitempty"`
     ReplyMarkup *types.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
@@ -3392,12 +3784,17 @@ type EditMessageReplyMarkupOpts struct {
 
 // Use this method to edit only the reply markup of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 func (b *Bot) EditMessageReplyMarkup(opts *EditMessageReplyMarkupOpts) (*types.Message, error) {
+    return b.EditMessageReplyMarkupWithContext(context.Background(), opts)
+}
+
+// EditMessageReplyMarkupWithContext is the same as EditMessageReplyMarkup, but uses the given context for the underlying request.
+func (b *Bot) EditMessageReplyMarkupWithContext(ctx context.Context, opts *EditMessageReplyMarkupOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
-        params["chat_id"] = strconv.FormatInt(opts.ChatId, 10)
+        params["chat_id"] = opts.ChatId.String()
         params["message_id"] = strconv.FormatInt(opts.MessageId, 10)
         params["inline_message_id"] = opts.InlineMessageId
 
@@ -3412,7 +3809,7 @@ func (b *Bot) EditMessageReplyMarkup(opts *EditMessageReplyMarkupOpts) (*types.M
     }
 
 
-    r, err := b.Request("editMessageReplyMarkup", params, data_params)
+    r, err := b.RequestWithContext(ctx, "editMessageReplyMarkup", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3430,11 +3827,16 @@ type StopPollOpts struct {
 }
 
 // Use this method to stop a poll which was sent by the bot. On success, the stopped Poll is returned.
-func (b *Bot) StopPoll(chatId int64, messageId int64, opts *StopPollOpts) (*types.Poll, error) {
+func (b *Bot) StopPoll(chatId ChatID, messageId int64, opts *StopPollOpts) (*types.Poll, error) {
+    return b.StopPollWithContext(context.Background(), chatId, messageId, opts)
+}
+
+// StopPollWithContext is the same as StopPoll, but uses the given context for the underlying request.
+func (b *Bot) StopPollWithContext(ctx context.Context, chatId ChatID, messageId int64, opts *StopPollOpts) (*types.Poll, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["message_id"] = strconv.FormatInt(messageId, 10)
     if opts != nil {
         params["business_connection_id"] = opts.BusinessConnectionId
@@ -3450,7 +3852,7 @@ func (b *Bot) StopPoll(chatId int64, messageId int64, opts *StopPollOpts) (*type
     }
 
 
-    r, err := b.Request("stopPoll", params, data_params)
+    r, err := b.RequestWithContext(ctx, "stopPoll", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3471,13 +3873,18 @@ func (b *Bot) StopPoll(chatId int64, messageId int64, opts *StopPollOpts) (*type
 // - If the bot is an administrator of a group, it can delete any message there.
 // - If the bot has can_delete_messages permission in a supergroup or a channel, it can delete any message there.
 // Returns True on success.
-func (b *Bot) DeleteMessage(chatId int64, messageId int64) (bool, error) {
+func (b *Bot) DeleteMessage(chatId ChatID, messageId int64) (bool, error) {
+    return b.DeleteMessageWithContext(context.Background(), chatId, messageId)
+}
+
+// DeleteMessageWithContext is the same as DeleteMessage, but uses the given context for the underlying request.
+func (b *Bot) DeleteMessageWithContext(ctx context.Context, chatId ChatID, messageId int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
     params["message_id"] = strconv.FormatInt(messageId, 10)
 
-    r, err := b.Request("deleteMessage", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteMessage", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3488,10 +3895,15 @@ func (b *Bot) DeleteMessage(chatId int64, messageId int64) (bool, error) {
 }
 
 // Use this method to delete multiple messages simultaneously. If some of the specified messages can't be found, they are skipped. Returns True on success.
-func (b *Bot) DeleteMessages(chatId int64, messageIds []int64) (bool, error) {
+func (b *Bot) DeleteMessages(chatId ChatID, messageIds []int64) (bool, error) {
+    return b.DeleteMessagesWithContext(context.Background(), chatId, messageIds)
+}
+
+// DeleteMessagesWithContext is the same as DeleteMessages, but uses the given context for the underlying request.
+func (b *Bot) DeleteMessagesWithContext(ctx context.Context, chatId ChatID, messageIds []int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    data_params := map[string]types.InputFile{}
+    params["chat_id"] = chatId.String()
 
     if messageIds != nil {
         bs, err := json.Marshal(messageIds)
@@ -3502,7 +3914,7 @@ func (b *Bot) DeleteMessages(chatId int64, messageIds []int64) (bool, error) {
     }
 
 
-    r, err := b.Request("deleteMessages", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteMessages", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3525,24 +3937,23 @@ type SendStickerOpts struct {
 }
 
 // Use this method to send static .WEBP, animated .TGS, or video .WEBM stickers. On success, the sent Message is returned.
-func (b *Bot) SendSticker(chatId int64, sticker types.InputFile, opts *SendStickerOpts) (*types.Message, error) {
+func (b *Bot) SendSticker(chatId ChatID, sticker types.InputFile, opts *SendStickerOpts) (*types.Message, error) {
+    return b.SendStickerWithContext(context.Background(), chatId, sticker, opts)
+}
+
+// SendStickerWithContext is the same as SendSticker, but uses the given context for the underlying request.
+func (b *Bot) SendStickerWithContext(ctx context.Context, chatId ChatID, sticker types.InputFile, opts *SendStickerOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
 
     if sticker != nil {
-        switch f := sticker.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["sticker"] = f
-            } else {
-                params["sticker"] = "attach://sticker"
-                data_params["sticker"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", sticker)
+        if ref, ok := sticker.Reference(); ok {
+            params["sticker"] = ref
+        } else {
+            params["sticker"] = "attach://sticker"
+            data_params["sticker"] = sticker
         }
     }
     if opts != nil {
@@ -3573,7 +3984,7 @@ func (b *Bot) SendSticker(chatId int64, sticker types.InputFile, opts *SendStick
     }
 
 
-    r, err := b.Request("sendSticker", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendSticker", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3586,11 +3997,16 @@ func (b *Bot) SendSticker(chatId int64, sticker types.InputFile, opts *SendStick
 
 // Use this method to get a sticker set. On success, a StickerSet object is returned.
 func (b *Bot) GetStickerSet(name string) (*types.StickerSet, error) {
+    return b.GetStickerSetWithContext(context.Background(), name)
+}
+
+// GetStickerSetWithContext is the same as GetStickerSet, but uses the given context for the underlying request.
+func (b *Bot) GetStickerSetWithContext(ctx context.Context, name string) (*types.StickerSet, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["name"] = name
 
-    r, err := b.Request("getStickerSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getStickerSet", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3602,8 +4018,13 @@ func (b *Bot) GetStickerSet(name string) (*types.StickerSet, error) {
 
 // Use this method to get information about custom emoji stickers by their identifiers. Returns an Array of Sticker objects.
 func (b *Bot) GetCustomEmojiStickers(customEmojiIds []string) ([]types.Sticker, error) {
+    return b.GetCustomEmojiStickersWithContext(context.Background(), customEmojiIds)
+}
+
+// GetCustomEmojiStickersWithContext is the same as GetCustomEmojiStickers, but uses the given context for the underlying request.
+func (b *Bot) GetCustomEmojiStickersWithContext(ctx context.Context, customEmojiIds []string) ([]types.Sticker, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if customEmojiIds != nil {
         bs, err := json.Marshal(customEmojiIds)
@@ -3614,7 +4035,7 @@ func (b *Bot) GetCustomEmojiStickers(customEmojiIds []string) ([]types.Sticker,
     }
 
 
-    r, err := b.Request("getCustomEmojiStickers", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getCustomEmojiStickers", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3626,27 +4047,26 @@ func (b *Bot) GetCustomEmojiStickers(customEmojiIds []string) ([]types.Sticker,
 
 // Use this method to upload a file with a sticker for later use in the createNewStickerSet, addStickerToSet, or replaceStickerInSet methods (the file can be used multiple times). Returns the uploaded File on success.
 func (b *Bot) UploadStickerFile(userId int64, sticker types.InputFile, stickerFormat string) (*types.File, error) {
+    return b.UploadStickerFileWithContext(context.Background(), userId, sticker, stickerFormat)
+}
+
+// UploadStickerFileWithContext is the same as UploadStickerFile, but uses the given context for the underlying request.
+func (b *Bot) UploadStickerFileWithContext(ctx context.Context, userId int64, sticker types.InputFile, stickerFormat string) (*types.File, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["user_id"] = strconv.FormatInt(userId, 10)
 
     if sticker != nil {
-        switch f := sticker.(type) {
-        case string:
-            _, err := os.Stat(f)
-            if err != nil {
-                params["sticker"] = f
-            } else {
-                params["sticker"] = "attach://sticker"
-                data_params["sticker"] = f
-            }
-        default:
-            return nil, fmt.Errorf("unknown type for InputFile: %T", sticker)
+        if ref, ok := sticker.Reference(); ok {
+            params["sticker"] = ref
+        } else {
+            params["sticker"] = "attach://sticker"
+            data_params["sticker"] = sticker
         }
     }
     params["sticker_format"] = stickerFormat
 
-    r, err := b.Request("uploadStickerFile", params, data_params)
+    r, err := b.RequestWithContext(ctx, "uploadStickerFile", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -3664,15 +4084,28 @@ type CreateNewStickerSetOpts struct {
 
 // Use this method to create a new sticker set owned by a user. The bot will be able to edit the sticker set thus created. Returns True on success.
 func (b *Bot) CreateNewStickerSet(userId int64, name string, title string, stickers []types.InputSticker, opts *CreateNewStickerSetOpts) (bool, error) {
+    return b.CreateNewStickerSetWithContext(context.Background(), userId, name, title, stickers, opts)
+}
+
+// CreateNewStickerSetWithContext is the same as CreateNewStickerSet, but uses the given context for the underlying request.
+func (b *Bot) CreateNewStickerSetWithContext(ctx context.Context, userId int64, name string, title string, stickers []types.InputSticker, opts *CreateNewStickerSetOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["user_id"] = strconv.FormatInt(userId, 10)
     params["name"] = name
     params["title"] = title
 
     if stickers != nil {
-        bs, err := json.Marshal(stickers)
+        items := make([]json.RawMessage, len(stickers))
+        for i, v := range stickers {
+            bs, err := v.InputParams("stickers" + strconv.Itoa(i), data_params)
+            if err != nil {
+                return false, fmt.Errorf("failed to marshal field stickers: %w", err)
+            }
+            items[i] = bs
+        }
+        bs, err := json.Marshal(items)
         if err != nil {
             return false, fmt.Errorf("failed to marshal field stickers: %w", err)
         }
@@ -3685,7 +4118,7 @@ func (b *Bot) CreateNewStickerSet(userId int64, name string, title string, stick
     }
 
 
-    r, err := b.Request("createNewStickerSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "createNewStickerSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3698,13 +4131,18 @@ func (b *Bot) CreateNewStickerSet(userId int64, name string, title string, stick
 
 // Use this method to add a new sticker to a set created by the bot. Emoji sticker sets can have up to 200 stickers. Other sticker sets can have up to 120 stickers. Returns True on success.
 func (b *Bot) AddStickerToSet(userId int64, name string, sticker *types.InputSticker) (bool, error) {
+    return b.AddStickerToSetWithContext(context.Background(), userId, name, sticker)
+}
+
+// AddStickerToSetWithContext is the same as AddStickerToSet, but uses the given context for the underlying request.
+func (b *Bot) AddStickerToSetWithContext(ctx context.Context, userId int64, name string, sticker *types.InputSticker) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["user_id"] = strconv.FormatInt(userId, 10)
     params["name"] = name
 
     if sticker != nil {
-        bs, err := json.Marshal(sticker)
+        bs, err := sticker.InputParams("sticker", data_params)
         if err != nil {
             return false, fmt.Errorf("failed to marshal field sticker: %w", err)
         }
@@ -3712,7 +4150,7 @@ func (b *Bot) AddStickerToSet(userId int64, name string, sticker *types.InputSti
     }
 
 
-    r, err := b.Request("addStickerToSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "addStickerToSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3724,12 +4162,17 @@ func (b *Bot) AddStickerToSet(userId int64, name string, sticker *types.InputSti
 
 // Use this method to move a sticker in a set created by the bot to a specific position. Returns True on success.
 func (b *Bot) SetStickerPositionInSet(sticker string, position int64) (bool, error) {
+    return b.SetStickerPositionInSetWithContext(context.Background(), sticker, position)
+}
+
+// SetStickerPositionInSetWithContext is the same as SetStickerPositionInSet, but uses the given context for the underlying request.
+func (b *Bot) SetStickerPositionInSetWithContext(ctx context.Context, sticker string, position int64) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["sticker"] = sticker
     params["position"] = strconv.FormatInt(position, 10)
 
-    r, err := b.Request("setStickerPositionInSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setStickerPositionInSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3741,11 +4184,16 @@ func (b *Bot) SetStickerPositionInSet(sticker string, position int64) (bool, err
 
 // Use this method to delete a sticker from a set created by the bot. Returns True on success.
 func (b *Bot) DeleteStickerFromSet(sticker string) (bool, error) {
+    return b.DeleteStickerFromSetWithContext(context.Background(), sticker)
+}
+
+// DeleteStickerFromSetWithContext is the same as DeleteStickerFromSet, but uses the given context for the underlying request.
+func (b *Bot) DeleteStickerFromSetWithContext(ctx context.Context, sticker string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["sticker"] = sticker
 
-    r, err := b.Request("deleteStickerFromSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteStickerFromSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3757,14 +4205,19 @@ func (b *Bot) DeleteStickerFromSet(sticker string) (bool, error) {
 
 // Use this method to replace an existing sticker in a sticker set with a new one. The method is equivalent to calling deleteStickerFromSet, then addStickerToSet, then setStickerPositionInSet. Returns True on success.
 func (b *Bot) ReplaceStickerInSet(userId int64, name string, oldSticker string, sticker *types.InputSticker) (bool, error) {
+    return b.ReplaceStickerInSetWithContext(context.Background(), userId, name, oldSticker, sticker)
+}
+
+// ReplaceStickerInSetWithContext is the same as ReplaceStickerInSet, but uses the given context for the underlying request.
+func (b *Bot) ReplaceStickerInSetWithContext(ctx context.Context, userId int64, name string, oldSticker string, sticker *types.InputSticker) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["user_id"] = strconv.FormatInt(userId, 10)
     params["name"] = name
     params["old_sticker"] = oldSticker
 
     if sticker != nil {
-        bs, err := json.Marshal(sticker)
+        bs, err := sticker.InputParams("sticker", data_params)
         if err != nil {
             return false, fmt.Errorf("failed to marshal field sticker: %w", err)
         }
@@ -3772,7 +4225,7 @@ func (b *Bot) ReplaceStickerInSet(userId int64, name string, oldSticker string,
     }
 
 
-    r, err := b.Request("replaceStickerInSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "replaceStickerInSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3784,8 +4237,13 @@ func (b *Bot) ReplaceStickerInSet(userId int64, name string, oldSticker string,
 
 // Use this method to change the list of emoji assigned to a regular or custom emoji sticker. The sticker must belong to a sticker set created by the bot. Returns True on success.
 func (b *Bot) SetStickerEmojiList(sticker string, emojiList []string) (bool, error) {
+    return b.SetStickerEmojiListWithContext(context.Background(), sticker, emojiList)
+}
+
+// SetStickerEmojiListWithContext is the same as SetStickerEmojiList, but uses the given context for the underlying request.
+func (b *Bot) SetStickerEmojiListWithContext(ctx context.Context, sticker string, emojiList []string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["sticker"] = sticker
 
     if emojiList != nil {
@@ -3797,7 +4255,7 @@ func (b *Bot) SetStickerEmojiList(sticker string, emojiList []string) (bool, err
     }
 
 
-    r, err := b.Request("setStickerEmojiList", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setStickerEmojiList", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3814,8 +4272,13 @@ type SetStickerKeywordsOpts struct {
 
 // Use this method to change search keywords assigned to a regular or custom emoji sticker. The sticker must belong to a sticker set created by the bot. Returns True on success.
 func (b *Bot) SetStickerKeywords(sticker string, opts *SetStickerKeywordsOpts) (bool, error) {
+    return b.SetStickerKeywordsWithContext(context.Background(), sticker, opts)
+}
+
+// SetStickerKeywordsWithContext is the same as SetStickerKeywords, but uses the given context for the underlying request.
+func (b *Bot) SetStickerKeywordsWithContext(ctx context.Context, sticker string, opts *SetStickerKeywordsOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["sticker"] = sticker
     if opts != nil {
@@ -3831,7 +4294,7 @@ func (b *Bot) SetStickerKeywords(sticker string, opts *SetStickerKeywordsOpts) (
     }
 
 
-    r, err := b.Request("setStickerKeywords", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setStickerKeywords", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3849,8 +4312,13 @@ type SetStickerMaskPositionOpts struct {
 
 // Use this method to change the mask position of a mask sticker. The sticker must belong to a sticker set that was created by the bot. Returns True on success.
 func (b *Bot) SetStickerMaskPosition(sticker string, opts *SetStickerMaskPositionOpts) (bool, error) {
+    return b.SetStickerMaskPositionWithContext(context.Background(), sticker, opts)
+}
+
+// SetStickerMaskPositionWithContext is the same as SetStickerMaskPosition, but uses the given context for the underlying request.
+func (b *Bot) SetStickerMaskPositionWithContext(ctx context.Context, sticker string, opts *SetStickerMaskPositionOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["sticker"] = sticker
     if opts != nil {
@@ -3866,7 +4334,7 @@ func (b *Bot) SetStickerMaskPosition(sticker string, opts *SetStickerMaskPositio
     }
 
 
-    r, err := b.Request("setStickerMaskPosition", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setStickerMaskPosition", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3879,12 +4347,17 @@ func (b *Bot) SetStickerMaskPosition(sticker string, opts *SetStickerMaskPositio
 
 // Use this method to set the title of a created sticker set. Returns True on success.
 func (b *Bot) SetStickerSetTitle(name string, title string) (bool, error) {
+    return b.SetStickerSetTitleWithContext(context.Background(), name, title)
+}
+
+// SetStickerSetTitleWithContext is the same as SetStickerSetTitle, but uses the given context for the underlying request.
+func (b *Bot) SetStickerSetTitleWithContext(ctx context.Context, name string, title string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["name"] = name
     params["title"] = title
 
-    r, err := b.Request("setStickerSetTitle", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setStickerSetTitle", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3901,8 +4374,13 @@ type SetStickerSetThumbnailOpts struct {
 
 // Use this method to set the thumbnail of a regular or mask sticker set. The format of the thumbnail file must match the format of the stickers in the set. Returns True on success.
 func (b *Bot) SetStickerSetThumbnail(name string, userId int64, format string, opts *SetStickerSetThumbnailOpts) (bool, error) {
+    return b.SetStickerSetThumbnailWithContext(context.Background(), name, userId, format, opts)
+}
+
+// SetStickerSetThumbnailWithContext is the same as SetStickerSetThumbnail, but uses the given context for the underlying request.
+func (b *Bot) SetStickerSetThumbnailWithContext(ctx context.Context, name string, userId int64, format string, opts *SetStickerSetThumbnailOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["name"] = name
     params["user_id"] = strconv.FormatInt(userId, 10)
@@ -3910,23 +4388,17 @@ func (b *Bot) SetStickerSetThumbnail(name string, userId int64, format string, o
     if opts != nil {
 
         if opts.Thumbnail != nil {
-            switch f := opts.Thumbnail.(type) {
-            case string:
-                _, err := os.Stat(f)
-                if err != nil {
-                    params["thumbnail"] = f
-                } else {
-                    params["thumbnail"] = "attach://thumbnail"
-                    data_params["thumbnail"] = f
-                }
-            default:
-                return false, fmt.Errorf("unknown type for InputFile: %T", opts.Thumbnail)
+            if ref, ok := opts.Thumbnail.Reference(); ok {
+                params["thumbnail"] = ref
+            } else {
+                params["thumbnail"] = "attach://thumbnail"
+                data_params["thumbnail"] = opts.Thumbnail
             }
         }
     }
 
 
-    r, err := b.Request("setStickerSetThumbnail", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setStickerSetThumbnail", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3944,8 +4416,13 @@ type SetCustomEmojiStickerSetThumbnailOpts struct {
 
 // Use this method to set the thumbnail of a custom emoji sticker set. Returns True on success.
 func (b *Bot) SetCustomEmojiStickerSetThumbnail(name string, opts *SetCustomEmojiStickerSetThumbnailOpts) (bool, error) {
+    return b.SetCustomEmojiStickerSetThumbnailWithContext(context.Background(), name, opts)
+}
+
+// SetCustomEmojiStickerSetThumbnailWithContext is the same as SetCustomEmojiStickerSetThumbnail, but uses the given context for the underlying request.
+func (b *Bot) SetCustomEmojiStickerSetThumbnailWithContext(ctx context.Context, name string, opts *SetCustomEmojiStickerSetThumbnailOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["name"] = name
     if opts != nil {
@@ -3953,7 +4430,7 @@ func (b *Bot) SetCustomEmojiStickerSetThumbnail(name string, opts *SetCustomEmoj
     }
 
 
-    r, err := b.Request("setCustomEmojiStickerSetThumbnail", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setCustomEmojiStickerSetThumbnail", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3966,11 +4443,16 @@ func (b *Bot) SetCustomEmojiStickerSetThumbnail(name string, opts *SetCustomEmoj
 
 // Use this method to delete a sticker set that was created by the bot. Returns True on success.
 func (b *Bot) DeleteStickerSet(name string) (bool, error) {
+    return b.DeleteStickerSetWithContext(context.Background(), name)
+}
+
+// DeleteStickerSetWithContext is the same as DeleteStickerSet, but uses the given context for the underlying request.
+func (b *Bot) DeleteStickerSetWithContext(ctx context.Context, name string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["name"] = name
 
-    r, err := b.Request("deleteStickerSet", params, data_params)
+    r, err := b.RequestWithContext(ctx, "deleteStickerSet", params, data_params)
     if err != nil {
         return false, err
     }
@@ -3991,8 +4473,13 @@ type AnswerInlineQueryOpts struct {
 // Use this method to send answers to an inline query. On success, True is returned.
 // No more than 50 results per query are allowed.
 func (b *Bot) AnswerInlineQuery(inlineQueryId string, results []types.InlineQueryResult, opts *AnswerInlineQueryOpts) (bool, error) {
+    return b.AnswerInlineQueryWithContext(context.Background(), inlineQueryId, results, opts)
+}
+
+// AnswerInlineQueryWithContext is the same as AnswerInlineQuery, but uses the given context for the underlying request.
+func (b *Bot) AnswerInlineQueryWithContext(ctx context.Context, inlineQueryId string, results []types.InlineQueryResult, opts *AnswerInlineQueryOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["inline_query_id"] = inlineQueryId
 
@@ -4020,7 +4507,7 @@ func (b *Bot) AnswerInlineQuery(inlineQueryId string, results []types.InlineQuer
     }
 
 
-    r, err := b.Request("answerInlineQuery", params, data_params)
+    r, err := b.RequestWithContext(ctx, "answerInlineQuery", params, data_params)
     if err != nil {
         return false, err
     }
@@ -4033,8 +4520,13 @@ func (b *Bot) AnswerInlineQuery(inlineQueryId string, results []types.InlineQuer
 
 // Use this method to set the result of an interaction with a Web App and send a corresponding message on behalf of the user to the chat from which the query originated. On success, a SentWebAppMessage object is returned.
 func (b *Bot) AnswerWebAppQuery(webAppQueryId string, result *types.InlineQueryResult) (*types.SentWebAppMessage, error) {
+    return b.AnswerWebAppQueryWithContext(context.Background(), webAppQueryId, result)
+}
+
+// AnswerWebAppQueryWithContext is the same as AnswerWebAppQuery, but uses the given context for the underlying request.
+func (b *Bot) AnswerWebAppQueryWithContext(ctx context.Context, webAppQueryId string, result *types.InlineQueryResult) (*types.SentWebAppMessage, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["web_app_query_id"] = webAppQueryId
 
     if result != nil {
@@ -4046,7 +4538,7 @@ func (b *Bot) AnswerWebAppQuery(webAppQueryId string, result *types.InlineQueryR
     }
 
 
-    r, err := b.Request("answerWebAppQuery", params, data_params)
+    r, err := b.RequestWithContext(ctx, "answerWebAppQuery", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -4083,11 +4575,16 @@ type SendInvoiceOpts struct {
 }
 
 // Use this method to send invoices. On success, the sent Message is returned.
-func (b *Bot) SendInvoice(chatId int64, title string, description string, payload string, currency string, prices []types.LabeledPrice, opts *SendInvoiceOpts) (*types.Message, error) {
+func (b *Bot) SendInvoice(chatId ChatID, title string, description string, payload string, currency string, prices []types.LabeledPrice, opts *SendInvoiceOpts) (*types.Message, error) {
+    return b.SendInvoiceWithContext(context.Background(), chatId, title, description, payload, currency, prices, opts)
+}
+
+// SendInvoiceWithContext is the same as SendInvoice, but uses the given context for the underlying request.
+func (b *Bot) SendInvoiceWithContext(ctx context.Context, chatId ChatID, title string, description string, payload string, currency string, prices []types.LabeledPrice, opts *SendInvoiceOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
-    params["chat_id"] = strconv.FormatInt(chatId, 10)
+    params["chat_id"] = chatId.String()
     params["title"] = title
     params["description"] = description
     params["payload"] = payload
@@ -4151,7 +4648,7 @@ func (b *Bot) SendInvoice(chatId int64, title string, description string, payloa
     }
 
 
-    r, err := b.Request("sendInvoice", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendInvoice", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -4183,8 +4680,13 @@ type CreateInvoiceLinkOpts struct {
 
 // Use this method to create a link for an invoice. Returns the created invoice link as String on success.
 func (b *Bot) CreateInvoiceLink(title string, description string, payload string, currency string, prices []types.LabeledPrice, opts *CreateInvoiceLinkOpts) (string, error) {
+    return b.CreateInvoiceLinkWithContext(context.Background(), title, description, payload, currency, prices, opts)
+}
+
+// CreateInvoiceLinkWithContext is the same as CreateInvoiceLink, but uses the given context for the underlying request.
+func (b *Bot) CreateInvoiceLinkWithContext(ctx context.Context, title string, description string, payload string, currency string, prices []types.LabeledPrice, opts *CreateInvoiceLinkOpts) (string, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["title"] = title
     params["description"] = description
@@ -4226,7 +4728,7 @@ func (b *Bot) CreateInvoiceLink(title string, description string, payload string
     }
 
 
-    r, err := b.Request("createInvoiceLink", params, data_params)
+    r, err := b.RequestWithContext(ctx, "createInvoiceLink", params, data_params)
     if err != nil {
         return "", err
     }
@@ -4245,8 +4747,13 @@ type AnswerShippingQueryOpts struct {
 
 // If you sent an invoice requesting a shipping address and the parameter is_flexible was specified, the Bot API will send an Update with a shipping_query field to the bot. Use this method to reply to shipping queries. On success, True is returned.
 func (b *Bot) AnswerShippingQuery(shippingQueryId string, ok bool, opts *AnswerShippingQueryOpts) (bool, error) {
+    return b.AnswerShippingQueryWithContext(context.Background(), shippingQueryId, ok, opts)
+}
+
+// AnswerShippingQueryWithContext is the same as AnswerShippingQuery, but uses the given context for the underlying request.
+func (b *Bot) AnswerShippingQueryWithContext(ctx context.Context, shippingQueryId string, ok bool, opts *AnswerShippingQueryOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["shipping_query_id"] = shippingQueryId
     params["ok"] = strconv.FormatBool(ok)
@@ -4264,7 +4771,7 @@ func (b *Bot) AnswerShippingQuery(shippingQueryId string, ok bool, opts *AnswerS
     }
 
 
-    r, err := b.Request("answerShippingQuery", params, data_params)
+    r, err := b.RequestWithContext(ctx, "answerShippingQuery", params, data_params)
     if err != nil {
         return false, err
     }
@@ -4282,8 +4789,13 @@ type AnswerPreCheckoutQueryOpts struct {
 
 // Once the user has confirmed their payment and shipping details, the Bot API sends the final confirmation in the form of an Update with the field pre_checkout_query. Use this method to respond to such pre-checkout queries. On success, True is returned. Note: The Bot API must receive an answer within 10 seconds after the pre-checkout query was sent.
 func (b *Bot) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok bool, opts *AnswerPreCheckoutQueryOpts) (bool, error) {
+    return b.AnswerPreCheckoutQueryWithContext(context.Background(), preCheckoutQueryId, ok, opts)
+}
+
+// AnswerPreCheckoutQueryWithContext is the same as AnswerPreCheckoutQuery, but uses the given context for the underlying request.
+func (b *Bot) AnswerPreCheckoutQueryWithContext(ctx context.Context, preCheckoutQueryId string, ok bool, opts *AnswerPreCheckoutQueryOpts) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["pre_checkout_query_id"] = preCheckoutQueryId
     params["ok"] = strconv.FormatBool(ok)
@@ -4292,7 +4804,7 @@ func (b *Bot) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok bool, opts *A
     }
 
 
-    r, err := b.Request("answerPreCheckoutQuery", params, data_params)
+    r, err := b.RequestWithContext(ctx, "answerPreCheckoutQuery", params, data_params)
     if err != nil {
         return false, err
     }
@@ -4311,8 +4823,13 @@ type GetStarTransactionsOpts struct {
 
 // Returns the bot's Telegram Star transactions in chronological order. On success, returns a StarTransactions object.
 func (b *Bot) GetStarTransactions(opts *GetStarTransactionsOpts) (*types.StarTransactions, error) {
+    return b.GetStarTransactionsWithContext(context.Background(), opts)
+}
+
+// GetStarTransactionsWithContext is the same as GetStarTransactions, but uses the given context for the underlying request.
+func (b *Bot) GetStarTransactionsWithContext(ctx context.Context, opts *GetStarTransactionsOpts) (*types.StarTransactions, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     if opts != nil {
         params["offset"] = strconv.FormatInt(opts.Offset, 10)
@@ -4320,7 +4837,7 @@ func (b *Bot) GetStarTransactions(opts *GetStarTransactionsOpts) (*types.StarTra
     }
 
 
-    r, err := b.Request("getStarTransactions", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getStarTransactions", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -4333,12 +4850,17 @@ func (b *Bot) GetStarTransactions(opts *GetStarTransactionsOpts) (*types.StarTra
 
 // Refunds a successful payment in Telegram Stars. Returns True on success.
 func (b *Bot) RefundStarPayment(userId int64, telegramPaymentChargeId string) (bool, error) {
+    return b.RefundStarPaymentWithContext(context.Background(), userId, telegramPaymentChargeId)
+}
+
+// RefundStarPaymentWithContext is the same as RefundStarPayment, but uses the given context for the underlying request.
+func (b *Bot) RefundStarPaymentWithContext(ctx context.Context, userId int64, telegramPaymentChargeId string) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["user_id"] = strconv.FormatInt(userId, 10)
     params["telegram_payment_charge_id"] = telegramPaymentChargeId
 
-    r, err := b.Request("refundStarPayment", params, data_params)
+    r, err := b.RequestWithContext(ctx, "refundStarPayment", params, data_params)
     if err != nil {
         return false, err
     }
@@ -4351,8 +4873,13 @@ func (b *Bot) RefundStarPayment(userId int64, telegramPaymentChargeId string) (b
 // Informs a user that some of the Telegram Passport elements they provided contains errors. The user will not be able to re-submit their Passport to you until the errors are fixed (the contents of the field for which you returned the error must change). Returns True on success.
 // Use this if the data submitted by the user doesn't satisfy the standards your service requires for any reason. For example, if a birthday date seems invalid, a submitted document is blurry, a scan shows evidence of tampering, etc. Supply some details in the error message to make sure the user knows how to correct the issues.
 func (b *Bot) SetPassportDataErrors(userId int64, errors []types.PassportElementError) (bool, error) {
+    return b.SetPassportDataErrorsWithContext(context.Background(), userId, errors)
+}
+
+// SetPassportDataErrorsWithContext is the same as SetPassportDataErrors, but uses the given context for the underlying request.
+func (b *Bot) SetPassportDataErrorsWithContext(ctx context.Context, userId int64, errors []types.PassportElementError) (bool, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
     params["user_id"] = strconv.FormatInt(userId, 10)
 
     if errors != nil {
@@ -4364,7 +4891,7 @@ func (b *Bot) SetPassportDataErrors(userId int64, errors []types.PassportElement
     }
 
 
-    r, err := b.Request("setPassportDataErrors", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setPassportDataErrors", params, data_params)
     if err != nil {
         return false, err
     }
@@ -4387,8 +4914,13 @@ type SendGameOpts struct {
 
 // Use this method to send a game. On success, the sent Message is returned.
 func (b *Bot) SendGame(chatId int64, gameShortName string, opts *SendGameOpts) (*types.Message, error) {
+    return b.SendGameWithContext(context.Background(), chatId, gameShortName, opts)
+}
+
+// SendGameWithContext is the same as SendGame, but uses the given context for the underlying request.
+func (b *Bot) SendGameWithContext(ctx context.Context, chatId int64, gameShortName string, opts *SendGameOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["chat_id"] = strconv.FormatInt(chatId, 10)
     params["game_short_name"] = gameShortName
@@ -4419,7 +4951,7 @@ func (b *Bot) SendGame(chatId int64, gameShortName string, opts *SendGameOpts) (
     }
 
 
-    r, err := b.Request("sendGame", params, data_params)
+    r, err := b.RequestWithContext(ctx, "sendGame", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -4441,8 +4973,13 @@ type SetGameScoreOpts struct {
 
 // Use this method to set the score of the specified user in a game message. On success, if the message is not an inline message, the Message is returned, otherwise True is returned. Returns an error, if the new score is not greater than the user's current score in the chat and force is False.
 func (b *Bot) SetGameScore(userId int64, score int64, opts *SetGameScoreOpts) (*types.Message, error) {
+    return b.SetGameScoreWithContext(context.Background(), userId, score, opts)
+}
+
+// SetGameScoreWithContext is the same as SetGameScore, but uses the given context for the underlying request.
+func (b *Bot) SetGameScoreWithContext(ctx context.Context, userId int64, score int64, opts *SetGameScoreOpts) (*types.Message, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["user_id"] = strconv.FormatInt(userId, 10)
     params["score"] = strconv.FormatInt(score, 10)
@@ -4455,7 +4992,7 @@ func (b *Bot) SetGameScore(userId int64, score int64, opts *SetGameScoreOpts) (*
     }
 
 
-    r, err := b.Request("setGameScore", params, data_params)
+    r, err := b.RequestWithContext(ctx, "setGameScore", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -4475,8 +5012,13 @@ type GetGameHighScoresOpts struct {
 
 // Use this method to get data for high score tables. Will return the score of the specified user and several of their neighbors in a game. Returns an Array of GameHighScore objects.
 func (b *Bot) GetGameHighScores(userId int64, opts *GetGameHighScoresOpts) ([]types.GameHighScore, error) {
+    return b.GetGameHighScoresWithContext(context.Background(), userId, opts)
+}
+
+// GetGameHighScoresWithContext is the same as GetGameHighScores, but uses the given context for the underlying request.
+func (b *Bot) GetGameHighScoresWithContext(ctx context.Context, userId int64, opts *GetGameHighScoresOpts) ([]types.GameHighScore, error) {
     params := map[string]string{}
-    data_params := map[string]string{}
+    data_params := map[string]types.InputFile{}
 
     params["user_id"] = strconv.FormatInt(userId, 10)
     if opts != nil {
@@ -4486,7 +5028,7 @@ func (b *Bot) GetGameHighScores(userId int64, opts *GetGameHighScoresOpts) ([]ty
     }
 
 
-    r, err := b.Request("getGameHighScores", params, data_params)
+    r, err := b.RequestWithContext(ctx, "getGameHighScores", params, data_params)
     if err != nil {
         return nil, err
     }
@@ -0,0 +1,26 @@
+package bot
+
+// Middleware wraps every handler call made by the Dispatcher. It can run code before and after
+// calling next, enrich the Context, inspect the returned error, or skip next entirely to
+// short-circuit the handler.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Use adds middlewares around all handlers. Middlewares run in the order they were added,
+// the first one being the outermost.
+func (d *Dispatcher) Use(middlewares ...Middleware) {
+	d.handlersMu.Lock()
+	defer d.handlersMu.Unlock()
+
+	d.middlewares = append(d.middlewares, middlewares...)
+}
+
+// wrap builds the middleware chain around fn.
+func (d *Dispatcher) wrap(fn HandlerFunc) HandlerFunc {
+	d.handlersMu.RLock()
+	defer d.handlersMu.RUnlock()
+
+	for i := len(d.middlewares) - 1; i >= 0; i-- {
+		fn = d.middlewares[i](fn)
+	}
+	return fn
+}
@@ -2,51 +2,143 @@ package bot
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"os"
+
+	"github.com/KeralaBots/GoTGramBot/types"
 )
 
-func generateContentType(params map[string]string, files map[string]string, custom_byte *bytes.Buffer) (string, error) {
-	writer := multipart.NewWriter(custom_byte)
+// UploadProgress reports how much of a file has been sent.
+type UploadProgress struct {
+	Method   string
+	Field    string
+	FileName string
+	Sent     int64
+	// -1 when the size is not known in advance, e.g. for readers.
+	Total int64
+}
 
-	for key, value := range params {
-		writer.WriteField(key, value)
+type uploadProgressKey struct{}
+
+// WithUploadProgress returns a context which makes API calls using it report the progress of their uploads to fn.
+func WithUploadProgress(ctx context.Context, fn func(p UploadProgress)) context.Context {
+	return context.WithValue(ctx, uploadProgressKey{}, fn)
+}
+
+func uploadProgress(ctx context.Context) func(p UploadProgress) {
+	fn, _ := ctx.Value(uploadProgressKey{}).(func(p UploadProgress))
+	return fn
+}
+
+// multipartBody encodes params and files as multipart/form-data. Bodies without files are buffered,
+// while files are streamed through a pipe as the request is sent, so they are never held in memory as a whole.
+// wait must be called once the request is done; it returns the error which interrupted the streaming, if any.
+func multipartBody(ctx context.Context, method string, params map[string]string, files map[string]types.InputFile) (body io.ReadCloser, contentType string, wait func() error, err error) {
+	if len(files) == 0 {
+		buf := &bytes.Buffer{}
+		writer := multipart.NewWriter(buf)
+		if err := writeFields(writer, params); err != nil {
+			return nil, "", nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", nil, err
+		}
+		return io.NopCloser(buf), writer.FormDataContentType(), func() error { return nil }, nil
 	}
 
-	if len(files) > 0 {
-		for key, value := range files {
-			writeMultipart(value, writer, key)
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	progress := uploadProgress(ctx)
+
+	done := make(chan error, 1)
+	go func() {
+		err := writeFields(writer, params)
+		for key, file := range files {
+			if err != nil {
+				break
+			}
+			err = writeMultipart(file, writer, key, method, progress)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	wait = func() error {
+		pr.Close()
+		if err := <-done; err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			return err
 		}
+		return nil
 	}
 
-	err := writer.Close()
+	return pr, writer.FormDataContentType(), wait, nil
+}
 
-	return writer.FormDataContentType(), err
+func writeFields(writer *multipart.Writer, params map[string]string) error {
+	for key, value := range params {
+		if err := writer.WriteField(key, value); err != nil {
+			return fmt.Errorf("failed to write field %s: %w", key, err)
+		}
+	}
+	return nil
 }
 
-func writeMultipart(path string, writer *multipart.Writer, key string) error {
-	file, err := os.Open(path)
+func writeMultipart(file types.InputFile, writer *multipart.Writer, key string, method string, progress func(p UploadProgress)) error {
+	name, content, err := file.Open()
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return fmt.Errorf("failed to open %s: %w", key, err)
 	}
-	fileContent, err := io.ReadAll(file)
+	defer content.Close()
+
+	part, err := writer.CreateFormFile(key, name)
 	if err != nil {
-		return fmt.Errorf("failed to read file content: %w", err)
+		return fmt.Errorf("failed to create multipart form: %w", err)
 	}
-	fi, err := file.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to get file content: %w", err)
+
+	if progress != nil {
+		part = &progressWriter{
+			w:        part,
+			progress: progress,
+			state:    UploadProgress{Method: method, Field: key, FileName: name, Total: contentSize(content)},
+		}
 	}
-	file.Close()
 
-	part, err := writer.CreateFormFile(key, fi.Name())
-	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %w", err)
+	if _, err := io.Copy(part, content); err != nil {
+		return fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	return nil
+}
+
+// contentSize returns the size of content if it can be known without reading it, or -1.
+func contentSize(content io.Reader) int64 {
+	switch c := content.(type) {
+	case *os.File:
+		if fi, err := c.Stat(); err == nil && fi.Mode().IsRegular() {
+			return fi.Size()
+		}
+	case interface{ Len() int }:
+		return int64(c.Len())
 	}
+	return -1
+}
 
-	part.Write(fileContent)
+type progressWriter struct {
+	w        io.Writer
+	progress func(p UploadProgress)
+	state    UploadProgress
+}
 
-	return err
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.state.Sent += int64(n)
+	p.progress(p.state)
+	return n, err
 }
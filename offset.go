@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/KeralaBots/GoTGramBot/storage"
+)
+
+// OffsetStore persists the polling offset so a restarted bot resumes from the first update it has not acknowledged.
+type OffsetStore interface {
+	// LoadOffset returns the saved offset, and false if none was saved yet.
+	LoadOffset() (int64, bool, error)
+	SaveOffset(offset int64) error
+}
+
+type storageOffsetStore struct {
+	storage storage.Storage
+	key     storage.Key
+}
+
+// NewStorageOffsetStore keeps the offset of b in s.
+func NewStorageOffsetStore(s storage.Storage, b *Bot) OffsetStore {
+	return &storageOffsetStore{
+		storage: s,
+		key:     storage.Key{BotId: b.Id(), Name: "offset"},
+	}
+}
+
+func (s *storageOffsetStore) LoadOffset() (int64, bool, error) {
+	data, ok, err := s.storage.Get(s.key)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	offset, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid saved offset %q: %w", data, err)
+	}
+	return offset, true, nil
+}
+
+func (s *storageOffsetStore) SaveOffset(offset int64) error {
+	return s.storage.Set(s.key, []byte(strconv.FormatInt(offset, 10)), 0)
+}
+
+// resume prepares the offset before polling starts, either dropping the pending updates
+// or continuing from the offset saved in OffsetStore.
+func (d *Dispatcher) resume() {
+	if d.DropPendingUpdates {
+		if _, err := d.Bot.DeleteWebhookWithContext(d.ctx, &DeleteWebhookOpts{DropPendingUpdates: true}); err != nil {
+			log.Printf("failed to drop pending updates: %v", err)
+		}
+		return
+	}
+
+	if d.OffsetStore == nil {
+		return
+	}
+	offset, ok, err := d.OffsetStore.LoadOffset()
+	if err != nil {
+		log.Printf("failed to load offset: %v", err)
+		return
+	}
+	if ok {
+		d.Offset = offset
+	}
+}
+
+// saveOffset stores the offset once Telegram has been told about it.
+func (d *Dispatcher) saveOffset() {
+	if d.OffsetStore == nil || d.Offset <= 0 || d.Offset == d.savedOffset {
+		return
+	}
+	if err := d.OffsetStore.SaveOffset(d.Offset); err != nil {
+		log.Printf("failed to save offset %d: %v", d.Offset, err)
+		return
+	}
+	d.savedOffset = d.Offset
+}
@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimits describes how many messages may be sent to Telegram. Only the methods sending, copying, forwarding
+// or editing messages in a chat are limited; everything else, e.g. sendChatAction, editForumTopic or deleteMessage,
+// is sent right away.
+type RateLimits struct {
+	// Requests per second across all chats.
+	Global int
+	// Requests per PrivateInterval in a single private chat.
+	PrivateChat     int
+	PrivateInterval time.Duration
+	// Requests per GroupInterval in a single group, supergroup or channel.
+	Group         int
+	GroupInterval time.Duration
+}
+
+// DefaultRateLimits returns the limits documented in the Bot API FAQ.
+func DefaultRateLimits() *RateLimits {
+	return &RateLimits{
+		Global:          30,
+		PrivateChat:     1,
+		PrivateInterval: time.Second,
+		Group:           20,
+		GroupInterval:   time.Minute,
+	}
+}
+
+// RateLimiter delays requests so they stay within RateLimits. Calls are served in the order they arrive.
+type RateLimiter struct {
+	limits RateLimits
+	gap    time.Duration
+
+	mu     sync.Mutex
+	global []time.Time
+	chats  map[string][]time.Time
+	calls  int
+}
+
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	l := &RateLimiter{
+		limits: limits,
+		chats:  make(map[string][]time.Time),
+	}
+	if limits.Global > 0 {
+		l.gap = time.Second / time.Duration(limits.Global)
+	}
+
+	return l
+}
+
+// Wait blocks until a request to chatId may be sent, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, chatId string) error {
+	now := time.Now()
+	at := l.reserve(chatId, now)
+	wait := at.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.release(chatId, at)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve books the earliest slot a request to chatId may be sent at.
+func (l *RateLimiter) reserve(chatId string, now time.Time) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.calls%1000 == 0 {
+		l.sweep(now)
+	}
+
+	at := now
+	limit, interval := l.chatLimit(chatId)
+	times := l.chats[chatId]
+	if limit > 0 && len(times) >= limit {
+		if next := times[len(times)-limit].Add(interval); next.After(at) {
+			at = next
+		}
+	}
+
+	at = l.reserveGlobal(at, now)
+
+	if limit > 0 {
+		times = append(times, at)
+		if len(times) > limit {
+			times = times[len(times)-limit:]
+		}
+		l.chats[chatId] = times
+	}
+
+	return at
+}
+
+// release gives back a slot booked by reserve for a request which was never sent.
+func (l *RateLimiter) release(chatId string, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.global = removeTime(l.global, at)
+	if times, ok := l.chats[chatId]; ok {
+		l.chats[chatId] = removeTime(times, at)
+	}
+}
+
+func removeTime(times []time.Time, at time.Time) []time.Time {
+	for i, t := range times {
+		if t.Equal(at) {
+			return append(times[:i], times[i+1:]...)
+		}
+	}
+	return times
+}
+
+// rateLimitedMethods are the methods which send, copy, forward or edit a message in a chat.
+var rateLimitedMethods = map[string]bool{
+	"sendMessage": true, "forwardMessage": true, "forwardMessages": true, "copyMessage": true, "copyMessages": true,
+	"sendPhoto": true, "sendAudio": true, "sendDocument": true, "sendVideo": true, "sendAnimation": true,
+	"sendVoice": true, "sendVideoNote": true, "sendPaidMedia": true, "sendMediaGroup": true, "sendLocation": true,
+	"sendVenue": true, "sendContact": true, "sendPoll": true, "sendDice": true, "sendSticker": true,
+	"sendInvoice": true, "sendGame": true, "editMessageText": true, "editMessageCaption": true,
+	"editMessageMedia": true, "editMessageLiveLocation": true, "stopMessageLiveLocation": true,
+	"editMessageReplyMarkup": true, "stopPoll": true,
+}
+
+// rateLimited reports whether method sends a message to a chat and so counts towards RateLimits.
+func rateLimited(method string) bool {
+	return rateLimitedMethods[method]
+}
+
+// reserveGlobal finds the earliest slot at or after at that is at least gap away from every other reserved slot.
+func (l *RateLimiter) reserveGlobal(at time.Time, now time.Time) time.Time {
+	if l.gap <= 0 {
+		return at
+	}
+
+	i := 0
+	for i < len(l.global) && l.global[i].Add(l.gap).Before(now) {
+		i++
+	}
+	l.global = l.global[i:]
+
+	idx := len(l.global)
+	for j, t := range l.global {
+		if !t.Add(l.gap).After(at) {
+			continue
+		}
+		if !at.Add(l.gap).After(t) {
+			idx = j
+			break
+		}
+		at = t.Add(l.gap)
+	}
+
+	l.global = append(l.global, time.Time{})
+	copy(l.global[idx+1:], l.global[idx:])
+	l.global[idx] = at
+
+	return at
+}
+
+func (l *RateLimiter) chatLimit(chatId string) (int, time.Duration) {
+	if chatId == "" {
+		return 0, 0
+	}
+	if strings.HasPrefix(chatId, "-") || strings.HasPrefix(chatId, "@") {
+		return l.limits.Group, l.limits.GroupInterval
+	}
+	return l.limits.PrivateChat, l.limits.PrivateInterval
+}
+
+func (l *RateLimiter) sweep(now time.Time) {
+	for chatId, times := range l.chats {
+		_, interval := l.chatLimit(chatId)
+		if len(times) == 0 || times[len(times)-1].Add(interval).Before(now) {
+			delete(l.chats, chatId)
+		}
+	}
+}
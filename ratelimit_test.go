@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type reservation struct {
+	chatId string
+	now    time.Duration
+	want   time.Duration
+}
+
+func checkReservations(t *testing.T, l *RateLimiter, reservations []reservation) {
+	t.Helper()
+	for i, r := range reservations {
+		if got := l.reserve(r.chatId, t0.Add(r.now)).Sub(t0); got != r.want {
+			t.Errorf("reservation %d for %q at %s: got slot %s, want %s", i, r.chatId, r.now, got, r.want)
+		}
+	}
+}
+
+func TestRateLimiterPrivateChat(t *testing.T) {
+	l := NewRateLimiter(RateLimits{PrivateChat: 1, PrivateInterval: time.Second})
+	checkReservations(t, l, []reservation{
+		{"42", 0, 0},
+		{"42", 0, time.Second},
+		{"42", 0, 2 * time.Second},
+		// Another chat is not held up by the first one.
+		{"43", 0, 0},
+		{"42", 5 * time.Second, 5 * time.Second},
+	})
+}
+
+func TestRateLimiterGroup(t *testing.T) {
+	l := NewRateLimiter(RateLimits{PrivateChat: 1, PrivateInterval: time.Second, Group: 2, GroupInterval: time.Minute})
+	checkReservations(t, l, []reservation{
+		{"-100", 0, 0},
+		{"-100", time.Second, time.Second},
+		{"-100", 2 * time.Second, time.Minute},
+		{"-100", 2 * time.Second, time.Minute + time.Second},
+		// Usernames always refer to groups or channels.
+		{"@channel", 0, 0},
+		{"@channel", 0, 0},
+		{"@channel", 0, time.Minute},
+	})
+}
+
+func TestRateLimiterGlobalGap(t *testing.T) {
+	l := NewRateLimiter(RateLimits{Global: 10, PrivateChat: 1, PrivateInterval: time.Second})
+	gap := 100 * time.Millisecond
+	checkReservations(t, l, []reservation{
+		{"", 0, 0},
+		{"", 0, gap},
+		// Pushed back by its own chat, then booked without conflicting with the global slots.
+		{"42", 0, 2 * gap},
+		{"42", 0, time.Second + 2*gap},
+		// Fills the free slots between the ones already booked.
+		{"", 0, 3 * gap},
+		{"", 0, 4 * gap},
+	})
+}
+
+func TestRateLimiterReleaseGlobal(t *testing.T) {
+	l := NewRateLimiter(RateLimits{Global: 10})
+	gap := 100 * time.Millisecond
+	checkReservations(t, l, []reservation{
+		{"", 0, 0},
+		{"", 0, gap},
+		{"", 0, 2 * gap},
+	})
+
+	l.release("", t0.Add(gap))
+	checkReservations(t, l, []reservation{
+		{"", 0, gap},
+		{"", 0, 3 * gap},
+	})
+}
+
+func TestRateLimiterReleaseChat(t *testing.T) {
+	l := NewRateLimiter(RateLimits{Group: 3, GroupInterval: time.Minute})
+	checkReservations(t, l, []reservation{
+		{"-100", 0, 0},
+		{"-100", 10 * time.Second, 10 * time.Second},
+		{"-100", 20 * time.Second, 20 * time.Second},
+	})
+
+	l.release("-100", t0.Add(10*time.Second))
+	checkReservations(t, l, []reservation{
+		// Only two messages are left in the last minute, so this one goes out right away.
+		{"-100", 30 * time.Second, 30 * time.Second},
+		{"-100", 30 * time.Second, time.Minute},
+	})
+}
+
+func TestRateLimited(t *testing.T) {
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"sendMessage", true},
+		{"copyMessages", true},
+		{"forwardMessage", true},
+		{"editMessageText", true},
+		{"sendChatAction", false},
+		{"editChatInviteLink", false},
+		{"editForumTopic", false},
+		{"getChatMember", false},
+	}
+	for _, tt := range tests {
+		if got := rateLimited(tt.method); got != tt.want {
+			t.Errorf("rateLimited(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package bot
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/KeralaBots/GoTGramBot/types"
@@ -29,43 +30,122 @@ type FileReader struct {
 	File     []byte
 }
 
+// GetApiURL returns the URL of method on the production Bot API server.
 func GetApiURL(token string, method string) string {
 	return fmt.Sprintf("%s/bot%s/%s", APIURL, token, method)
 }
 
-func (b *Bot) Request(method string, params map[string]string, files map[string]string) (json.RawMessage, error) {
-	custom_byte := &bytes.Buffer{}
-	contentType, err := generateContentType(params, files, custom_byte)
+// GetApiURL returns the URL of method on the Bot API server and environment b is configured for.
+func (b *Bot) GetApiURL(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s%s", b.baseURL(), b.Token, b.environment(), method)
+}
+
+func (b *Bot) baseURL() string {
+	if b.BaseURL == "" {
+		return APIURL
+	}
+	return b.BaseURL
+}
+
+func (b *Bot) environment() string {
+	if b.UseTestEnvironment {
+		return "test/"
+	}
+	return ""
+}
+
+func (b *Bot) Request(method string, params map[string]string, files map[string]types.InputFile) (json.RawMessage, error) {
+	return b.RequestWithContext(context.Background(), method, params, files)
+}
+
+func (b *Bot) RequestWithContext(ctx context.Context, method string, params map[string]string, files map[string]types.InputFile) (json.RawMessage, error) {
+	if b.RetryPolicy == nil {
+		return b.request(ctx, method, params, files)
+	}
+
+	return b.RetryPolicy.do(ctx, method, func() (json.RawMessage, error) {
+		return b.request(ctx, method, params, files)
+	})
+}
+
+func (b *Bot) request(ctx context.Context, method string, params map[string]string, files map[string]types.InputFile) (json.RawMessage, error) {
+	if b.RateLimiter != nil && params["chat_id"] != "" && rateLimited(method) {
+		if err := b.RateLimiter.Wait(ctx, params["chat_id"]); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
+	if timeout := b.requestTimeout(method, params, files); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	reqBody, contentType, wait, err := multipartBody(ctx, method, params, files)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content-type: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, GetApiURL(b.Token, method), custom_byte)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.GetApiURL(method), reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("post request failed: %e", err)
+		wait()
+		return nil, fmt.Errorf("post request failed: %w", err)
 	}
 
 	req.Header.Set("Content-Type", contentType)
+	if b.UserAgent != "" {
+		req.Header.Set("User-Agent", b.UserAgent)
+	}
 
 	res, err := b.Client.Do(req)
+	if uploadErr := wait(); uploadErr != nil {
+		// Failing to read a file is not a network error, and must not be retried as one.
+		if err == nil {
+			res.Body.Close()
+		}
+		return nil, fmt.Errorf("failed to upload files: %w", uploadErr)
+	}
 
 	if err != nil {
-		return nil, fmt.Errorf("post execution failed: %e", err)
+		return nil, fmt.Errorf("post execution failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %e", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var response Response
-	json.Unmarshal(body, &response)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response (status %d): %w", res.StatusCode, err)
+	}
 
 	res.Body.Close()
 	if !response.Ok {
-		return nil, fmt.Errorf("telegram error [%d] : %s", response.ErrorCode, err.Error())
+		return nil, &TelegramError{
+			Method:      method,
+			Code:        response.ErrorCode,
+			Description: response.Description,
+			Parameters:  response.Parameters,
+		}
 	}
 
 	return response.Result, nil
 }
+
+// requestTimeout returns how long a call to method may take, or zero for no limit. Long polling
+// gets its own timeout on top of RequestTimeout, and uploads are not limited at all.
+func (b *Bot) requestTimeout(method string, params map[string]string, files map[string]types.InputFile) time.Duration {
+	if b.RequestTimeout <= 0 || len(files) > 0 {
+		return 0
+	}
+
+	timeout := b.RequestTimeout
+	if method == "getUpdates" {
+		if poll, err := strconv.ParseInt(params["timeout"], 10, 64); err == nil && poll > 0 {
+			timeout += time.Duration(poll) * time.Second
+		}
+	}
+	return timeout
+}
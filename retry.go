@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how Request retries failed API calls.
+type RetryPolicy struct {
+	// Total number of attempts, including the first one. Values below 2 disable retrying.
+	MaxAttempts int
+	// Delay before the first retry; doubled on every following attempt.
+	BaseDelay time.Duration
+	// Upper bound for the exponential backoff delay. Zero means no bound.
+	MaxDelay time.Duration
+	// Wait for the retry_after returned by Telegram instead of the backoff delay.
+	HonorRetryAfter bool
+	// Longest retry_after we are willing to wait for. Zero means no bound.
+	MaxRetryAfter time.Duration
+	// Telegram error codes that should be retried.
+	RetryableCodes []int
+	// Retry when the request could not reach the Bot API at all, e.g. DNS or connection failures.
+	// Timeouts and errors after the request was sent are never retried, as the call may already have been executed.
+	RetryNetworkErrors bool
+	// Called before sleeping for every retry.
+	OnRetry func(method string, attempt int, err error, wait time.Duration)
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Second * 30,
+		HonorRetryAfter: true,
+		MaxRetryAfter:   time.Minute,
+		RetryableCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+func (p *RetryPolicy) do(ctx context.Context, method string, fn func() (json.RawMessage, error)) (json.RawMessage, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := fn()
+		if err == nil || attempt >= p.MaxAttempts {
+			return res, err
+		}
+
+		wait, ok := p.delay(ctx, attempt, err)
+		if !ok {
+			return res, err
+		}
+
+		if p.OnRetry != nil {
+			p.OnRetry(method, attempt, err, wait)
+		}
+
+		if !sleepContext(ctx, wait) {
+			return nil, err
+		}
+	}
+}
+
+// delay reports whether err should be retried and how long to wait before doing so.
+func (p *RetryPolicy) delay(ctx context.Context, attempt int, err error) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && (backoff > p.MaxDelay || backoff <= 0) {
+		backoff = p.MaxDelay
+	}
+
+	var tgErr *TelegramError
+	if errors.As(err, &tgErr) {
+		if !p.retryableCode(tgErr.Code) {
+			return 0, false
+		}
+		if retryAfter := tgErr.RetryAfter(); p.HonorRetryAfter && retryAfter > 0 {
+			if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+				return 0, false
+			}
+			return retryAfter, true
+		}
+		return backoff, true
+	}
+
+	if p.RetryNetworkErrors && notSent(err) {
+		return backoff, true
+	}
+
+	return 0, false
+}
+
+// notSent reports whether err happened before the request reached the server, so retrying it cannot execute the call twice.
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (p *RetryPolicy) retryableCode(code int) bool {
+	for _, c := range p.RetryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
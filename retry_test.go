@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+// postError wraps err the way Request reports a failed HTTP call.
+func postError(err error) error {
+	return fmt.Errorf("post request failed: %w", &url.Error{Op: "Post", URL: "https://api.telegram.org/bot1:token/sendMessage", Err: err})
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := &RetryPolicy{
+		MaxAttempts:        3,
+		BaseDelay:          time.Second,
+		MaxDelay:           3 * time.Second,
+		HonorRetryAfter:    true,
+		MaxRetryAfter:      time.Minute,
+		RetryableCodes:     []int{429, 502},
+		RetryNetworkErrors: true,
+	}
+
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		wait    time.Duration
+		retry   bool
+	}{
+		{"retry_after under MaxRetryAfter", 1, &TelegramError{Code: 429, Parameters: &types.ResponseParameters{RetryAfter: 5}}, 5 * time.Second, true},
+		{"retry_after over MaxRetryAfter", 1, &TelegramError{Code: 429, Parameters: &types.ResponseParameters{RetryAfter: 120}}, 0, false},
+		{"retryable code backs off", 2, &TelegramError{Code: 502}, 2 * time.Second, true},
+		{"backoff is capped", 5, &TelegramError{Code: 502}, 3 * time.Second, true},
+		{"non-retryable code", 1, &TelegramError{Code: 400, Description: "Bad Request: chat not found"}, 0, false},
+		{"wrapped telegram error", 1, fmt.Errorf("rate limiter: %w", &TelegramError{Code: 502}), time.Second, true},
+		{"dns failure", 1, postError(&net.DNSError{Err: "no such host", Name: "api.telegram.org"}), time.Second, true},
+		{"dial failure", 1, postError(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", errors.New("connection refused"))}), time.Second, true},
+		{"read failure after sending", 1, postError(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}), 0, false},
+		{"timeout after sending", 1, postError(context.DeadlineExceeded), 0, false},
+		{"unknown error", 1, errors.New("failed to read response"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, retry := p.delay(context.Background(), tt.attempt, tt.err)
+			if wait != tt.wait || retry != tt.retry {
+				t.Errorf("delay = %s, %v, want %s, %v", wait, retry, tt.wait, tt.retry)
+			}
+		})
+	}
+}
+
+func TestRetryDelayIgnoresRetryAfter(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, RetryableCodes: []int{429}}
+	err := &TelegramError{Code: 429, Parameters: &types.ResponseParameters{RetryAfter: 120}}
+
+	if wait, retry := p.delay(context.Background(), 1, err); wait != time.Second || !retry {
+		t.Errorf("delay = %s, %v, want the backoff delay when HonorRetryAfter is off", wait, retry)
+	}
+}
+
+func TestRetryDelayNetworkErrorsDisabled(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+	err := postError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+	if _, retry := p.delay(context.Background(), 1, err); retry {
+		t.Error("dial error retried although RetryNetworkErrors is off")
+	}
+}
+
+func TestRetryDelayCancelled(t *testing.T) {
+	p := DefaultRetryPolicy()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, retry := p.delay(ctx, 1, &TelegramError{Code: 502}); retry {
+		t.Error("retried after the context was cancelled")
+	}
+}
+
+func TestRetryDo(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3, RetryableCodes: []int{502}}
+
+	calls := 0
+	_, err := p.do(context.Background(), "sendMessage", func() (json.RawMessage, error) {
+		calls++
+		return nil, &TelegramError{Code: 502}
+	})
+	if err == nil || calls != 3 {
+		t.Errorf("got %d calls and error %v, want 3 calls and the last error", calls, err)
+	}
+
+	calls = 0
+	_, err = p.do(context.Background(), "sendMessage", func() (json.RawMessage, error) {
+		calls++
+		return nil, postError(context.DeadlineExceeded)
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("got %d calls for a timeout after sending, want 1", calls)
+	}
+}
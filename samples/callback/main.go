@@ -8,10 +8,10 @@ import (
 	"github.com/KeralaBots/GoTGramBot/types"
 )
 
-func test1(b *bot.Bot, m *types.CallbackQuery) error {
+func test1(b *bot.Bot, ctx *bot.Context) error {
 	_, err := b.SendPhoto(
-		m.Message.Chat.Id,
-		"test.jpg",
+		bot.ID(ctx.EffectiveChat.Id),
+		types.FromPath("test.jpg"),
 		&bot.SendPhotoOpts{},
 	)
 
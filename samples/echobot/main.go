@@ -8,7 +8,7 @@ import (
 	"github.com/KeralaBots/GoTGramBot/types"
 )
 
-func start(b *bot.Bot, m *types.Message) error {
+func start(b *bot.Bot, ctx *bot.Context) error {
 	replyButton := [][]types.InlineKeyboardButton{
 		{
 			{
@@ -19,7 +19,7 @@ func start(b *bot.Bot, m *types.Message) error {
 	}
 
 	_, err := b.SendMessage(
-		m.Chat.Id,
+		bot.ID(ctx.EffectiveChat.Id),
 		"Hi",
 		&bot.SendMessageOpts{ReplyMarkup: types.InlineKeyboardMarkup{InlineKeyboard: replyButton}},
 	)
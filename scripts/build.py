@@ -18,6 +18,14 @@ TG_CORE_TYPES = {
 
 CORE_TYPES = ['int64', 'float64', 'bool', 'string']
 MARKUP = ['InlineKeyboardMarkup', 'ReplyKeyboardMarkup', 'ReplyKeyboardRemove', 'ForceReply']
+# Types written by hand in the types package instead of being generated.
+HAND_WRITTEN_TYPES = ['InputFile']
+# Method fields accepting either a numeric chat id or an @username, generated as ChatID. Fields documented
+# as Integer only, like chat_id of sendGame, stay int64.
+CHAT_ID_FIELDS = ['chat_id', 'from_chat_id']
+CHAT_ID_TYPES = ['Integer', 'String']
+# Types with fields taking a file to upload, like InputMediaPhoto, which are sent through their InputParams method.
+UPLOAD_TYPES = []
 
 ARRAY_TYPE = []
 ARRAY_OF_ARRAY_TYPE = []
@@ -34,6 +42,21 @@ subclass_temp = """func (v {class_name}) Get{method}() {class_name} {{
 }}
 """
 
+input_params_temp = """// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v {class_name}) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {{
+    type alias {class_name}
+    return json.Marshal(struct {{
+        alias
+{fields}
+    }}{{
+        alias: alias(v),
+{values}
+    }})
+}}
+
+"""
+
 returned_temp = """
     var res {extra}{returned}
     return res, json.Unmarshal(r, &res) 
@@ -73,34 +96,82 @@ req_params_temp = """
 
 opt_input_temp = """
         if opts.{name} != nil {{
-            switch f := opts.{name}.(type) {{
-            case string:
-                _, err := os.Stat(f)
+            if ref, ok := opts.{name}.Reference(); ok {{
+                params["{field_name}"] = ref
+            }} else {{
+                params["{field_name}"] = "attach://{field_name}"
+                data_params["{field_name}"] = opts.{name}
+            }}
+        }}
+"""
+
+opt_input_params_temp = """
+        if opts.{name} != nil {{
+            bs, err := opts.{name}.InputParams("{field_name}", data_params)
+            if err != nil {{
+                return {is_bool}, fmt.Errorf("failed to marshal field {field_name}: %w", err)
+            }}
+            params["{field_name}"] = string(bs)
+        }}
+
+"""
+
+req_input_params_temp = """
+    if {name} != nil {{
+        bs, err := {name}.InputParams("{field_name}", data_params)
+        if err != nil {{
+            return {is_bool}, fmt.Errorf("failed to marshal field {field_name}: %w", err)
+        }}
+        params["{field_name}"] = string(bs)
+    }}
+
+"""
+
+opt_input_params_array_temp = """
+        if opts.{name} != nil {{
+            items := make([]json.RawMessage, len(opts.{name}))
+            for i, v := range opts.{name} {{
+                bs, err := v.InputParams("{field_name}" + strconv.Itoa(i), data_params)
                 if err != nil {{
-                    params["{field_name}"] = f
-                }} else {{
-                    params["{field_name}"] = "attach://{field_name}"
-                    data_params["{field_name}"] = f
+                    return {is_bool}, fmt.Errorf("failed to marshal field {field_name}: %w", err)
                 }}
-            default:
-                return {is_bool}, fmt.Errorf("unknown type for InputFile: %T", opts.{name})
+                items[i] = bs
+            }}
+            bs, err := json.Marshal(items)
+            if err != nil {{
+                return {is_bool}, fmt.Errorf("failed to marshal field {field_name}: %w", err)
             }}
+            params["{field_name}"] = string(bs)
         }}
+
 """
 
-req_input_temp = """
+req_input_params_array_temp = """
     if {name} != nil {{
-        switch f := {name}.(type) {{
-        case string:
-            _, err := os.Stat(f)
+        items := make([]json.RawMessage, len({name}))
+        for i, v := range {name} {{
+            bs, err := v.InputParams("{field_name}" + strconv.Itoa(i), data_params)
             if err != nil {{
-                params["{field_name}"] = f
-            }} else {{
-                params["{field_name}"] = "attach://{field_name}"
-                data_params["{field_name}"] = f
+                return {is_bool}, fmt.Errorf("failed to marshal field {field_name}: %w", err)
             }}
-        default:
-            return {is_bool}, fmt.Errorf("unknown type for InputFile: %T", {name})
+            items[i] = bs
+        }}
+        bs, err := json.Marshal(items)
+        if err != nil {{
+            return {is_bool}, fmt.Errorf("failed to marshal field {field_name}: %w", err)
+        }}
+        params["{field_name}"] = string(bs)
+    }}
+
+"""
+
+req_input_temp = """
+    if {name} != nil {{
+        if ref, ok := {name}.Reference(); ok {{
+            params["{field_name}"] = ref
+        }} else {{
+            params["{field_name}"] = "attach://{field_name}"
+            data_params["{field_name}"] = {name}
         }}
     }}
 """
@@ -108,10 +179,15 @@ req_input_temp = """
 nofield_method_temp = """
 {comments}
 func (b *Bot) {struct_name}({fields}) ({returns}error) {{
+    return b.{struct_name}WithContext({call_args})
+}}
+
+// {struct_name}WithContext is the same as {struct_name}, but uses the given context for the underlying request.
+func (b *Bot) {struct_name}WithContext({ctx_fields}) ({returns}error) {{
     params := map[string]string{{}}
-    data_params := map[string]string{{}}
+    data_params := map[string]types.InputFile{{}}
 {params}
-    r, err := b.Request("{method_name}", params, data_params)
+    r, err := b.RequestWithContext(ctx, "{method_name}", params, data_params)
     if err != nil {{
         return {is_bool}, err
     }}
@@ -191,7 +267,31 @@ def get_field_text(field_name, def_types, field, comments=True):
     return text
 
 
-def get_inheritance(subclasses, subclass_dict, schema, name):
+def is_upload_field(field):
+    # Fields of types like InputMediaPhoto which can refer to a file uploaded as "attach://<file_attach_name>".
+    return 'attach://' in field.get('description')
+
+
+def get_input_params(name, upload_fields):
+    fields = ''
+    values = ''
+    for field in upload_fields:
+        field_name = field.get('name')
+        empty = '' if field.get('required') else ',omitempty'
+        fields += f'        {camel(field_name)} string `json:"{field_name}{empty}"`\n'
+        values += f'        {camel(field_name)}: attach(v.{camel(field_name)}, prefix+"_{field_name}", data),\n'
+    return input_params_temp.format(class_name=name, fields=fields[:-1], values=values[:-1])
+
+
+def get_parent_type(types):
+    # Fields accepting several subtypes of the same type, like the media of sendMediaGroup, take the parent type.
+    parents = {SUBCLASS_DICT.get(t.replace('Array of ', '')) for t in types}
+    if len(parents) != 1 or None in parents:
+        return types[0]
+    return types[0].replace(types[0].replace('Array of ', ''), parents.pop())
+
+
+def get_inheritance(subclasses, subclass_dict, schema, name, upload_fields):
     field_text = ''
     sub_fields_list = []
     for subclass in subclasses:
@@ -204,6 +304,10 @@ def get_inheritance(subclasses, subclass_dict, schema, name):
                 continue
             else:
                 sub_fields_list.append(sub_field_name)
+                if is_upload_field(sub_fields):
+                    upload_fields.append(sub_fields)
+                    text += get_field_text(sub_field_name, 'InputFile', sub_fields, comments=False)
+                    continue
                 for sub_types in sub_fields.get('types'):
                     sub_def_types = get_type(sub_types)
                     if "InputFile" in sub_def_types:
@@ -258,7 +362,7 @@ def method_fields(fields, method_name):
             if types == MARKUP:
                 field_type_text += 'types.ReplyMarkup'
             else:
-                field_type, extra = get_field_type(types[0])
+                field_type, extra = get_field_type(get_parent_type(types))
                 field_type_text += extra + field_type
         else:
             field_type, extra = get_field_type(types[0])
@@ -266,6 +370,8 @@ def method_fields(fields, method_name):
 
         if "InputFile" in field_type_text:
             field_type_text = field_type_text.replace("*", "")
+        if field.get("name") in CHAT_ID_FIELDS and types == CHAT_ID_TYPES:
+            field_type_text = 'ChatID'
         if field.get("required"):
             data_form = {'field': field.get('name'), 'type': field_type_text}
             field_list_r.append(data_form)
@@ -282,6 +388,17 @@ def method_fields(fields, method_name):
     return method_params, optional_field_list, field_list_r, field_list_o
 
 
+def construct_call_args(field_params):
+    names = [f.strip().split(' ')[0] for f in field_params.split(',') if f.strip()]
+    return ", ".join(["context.Background()"] + names)
+
+
+def construct_ctx_fields(field_params):
+    if field_params:
+        return "ctx context.Context, " + field_params
+    return "ctx context.Context"
+
+
 def construct_opts(struct_list):
     r = "\n    ".join(struct_list)
     return r
@@ -335,6 +452,11 @@ def format_params(raw_data, typed, param_name, required: bool = False, is_bool=F
             data = f'    params["{param_name}"] = strconv.FormatInt({raw_data}, 10)\n'
         else:
             data = f'        params["{param_name}"] = strconv.FormatInt(opts.{raw_data}, 10)\n'
+    elif typed == 'ChatID':
+        if required:
+            data = f'    params["{param_name}"] = {raw_data}.String()\n'
+        else:
+            data = f'        params["{param_name}"] = opts.{raw_data}.String()\n'
     elif typed == 'float64':
         if required:
             data = f'    params["{param_name}"] = strconv.FormatFloat({raw_data}, \'E\', -1, 64)\n'
@@ -345,6 +467,16 @@ def format_params(raw_data, typed, param_name, required: bool = False, is_bool=F
             data = f'    params["{param_name}"] = strconv.FormatBool({raw_data})\n'
         else:
             data = f'        params["{param_name}"] = strconv.FormatBool(opts.{raw_data})\n'
+    elif typed.lstrip('*[]').replace('types.', '') in UPLOAD_TYPES:
+        if typed.startswith('[]'):
+            temp = req_input_params_array_temp if required else opt_input_params_array_temp
+        else:
+            temp = req_input_params_temp if required else opt_input_params_temp
+        data = temp.format(
+            name=raw_data,
+            field_name=param_name,
+            is_bool=is_bool
+        )
     elif "types.InputFile" in typed:
         if required:
             data = req_input_temp.format(
@@ -381,17 +513,23 @@ def build_types(api_content: Union[dict, None]):
         schema = api_content.get('types')
         # SUBCLASS_DICT = {}
         for name, item in schema.items():
+            if name in HAND_WRITTEN_TYPES:
+                continue
             subclasses = item.get('subtypes')
             comments = "// " + "\n// ".join(item.get('description'))
             fields = item.get('fields')
+            upload_fields = []
             if subclasses and len(subclasses) != 0:
-                field_text = get_inheritance(subclasses, SUBCLASS_DICT, schema, name)
+                field_text = get_inheritance(subclasses, SUBCLASS_DICT, schema, name, upload_fields)
                 content += content_temp.format(
                     name=name,
                     mode="struct",
                     comments=comments,
                     fields=field_text[:-1]
                 )
+                if upload_fields:
+                    UPLOAD_TYPES.append(name)
+                    content += get_input_params(name, upload_fields)
 
             elif fields is None:
                 content += content_temp.format(
@@ -403,6 +541,10 @@ def build_types(api_content: Union[dict, None]):
             else:
                 field_text = ''
                 for field in fields:
+                    if is_upload_field(field):
+                        upload_fields.append(field)
+                        field_text += get_field_text(field.get('name'), 'InputFile', field)
+                        continue
                     text = ''
                     for types in field.get('types'):
                         field_name = field.get('name')
@@ -421,6 +563,10 @@ def build_types(api_content: Union[dict, None]):
                     fields=field_text[:-1]
                 )
 
+                if upload_fields:
+                    UPLOAD_TYPES.append(name)
+                    content += get_input_params(name, upload_fields)
+
                 if SUBCLASS_DICT.get(name):
                     method = SUBCLASS_DICT.get(name)
                     content += subclass_temp.format(
@@ -467,6 +613,8 @@ def build_methods(api_content: Union[dict, None]):
                         struct_params=struct_params,
                         comments=comments,
                         fields=field_params,
+                        call_args=construct_call_args(field_params),
+                        ctx_fields=construct_ctx_fields(field_params),
                         returns=returns,
                         single_returns=single_returns,
                         params=params,
@@ -478,6 +626,8 @@ def build_methods(api_content: Union[dict, None]):
                         struct_name=camel(name),
                         method_name=name,
                         fields=field_params,
+                        call_args=construct_call_args(field_params),
+                        ctx_fields=construct_ctx_fields(field_params),
                         returns=returns,
                         single_returns=single_returns,
                         params=params,
@@ -489,6 +639,8 @@ def build_methods(api_content: Union[dict, None]):
                     struct_name=camel(name),
                     method_name=name,
                     fields="",
+                    call_args=construct_call_args(""),
+                    ctx_fields=construct_ctx_fields(""),
                     returns=returns,
                     single_returns=single_returns,
                     params="",
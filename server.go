@@ -0,0 +1,35 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MoveToServer moves the bot to the Bot API server at baseURL, an empty baseURL meaning the cloud server.
+// The bot is logged out of the cloud server with LogOut, or closed on a self-hosted one with Close,
+// before b starts sending its requests to the new server. Stop receiving updates before calling it.
+// Telegram does not let a bot back into the cloud server for 10 minutes after LogOut, and Close
+// fails during the first 10 minutes after a bot was launched.
+func (b *Bot) MoveToServer(ctx context.Context, baseURL string) error {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if baseURL == "" {
+		baseURL = APIURL
+	}
+	if baseURL == b.baseURL() {
+		return nil
+	}
+
+	if b.baseURL() == APIURL {
+		if _, err := b.LogOutWithContext(ctx); err != nil {
+			return fmt.Errorf("failed to log out from %s: %w", APIURL, err)
+		}
+	} else {
+		if _, err := b.CloseWithContext(ctx); err != nil {
+			return fmt.Errorf("failed to close the bot on %s: %w", b.baseURL(), err)
+		}
+	}
+
+	b.BaseURL = baseURL
+	return nil
+}
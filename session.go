@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KeralaBots/GoTGramBot/storage"
+)
+
+const sessionContextKey = "session"
+
+// Session holds per-user data which is kept between updates. Values are stored as JSON,
+// so they come back as the types encoding/json decodes into (float64, string, map[string]interface{}, ...).
+type Session map[string]interface{}
+
+// Sessions returns a middleware which loads the session of the effective user in the effective chat
+// before every handler and saves it afterwards if it changed. Sessions which are not changed for ttl
+// are dropped; zero keeps them forever. Use SequentialPerUser or SequentialPerChat so updates of the same user
+// don't overwrite each other's changes.
+func Sessions(s storage.Storage, ttl time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(b *Bot, ctx *Context) error {
+			if ctx.EffectiveUser == nil {
+				return next(b, ctx)
+			}
+
+			key := storage.Key{BotId: b.Id(), UserId: ctx.EffectiveUser.Id, Name: sessionContextKey}
+			if ctx.EffectiveChat != nil {
+				key.ChatId = ctx.EffectiveChat.Id
+			}
+
+			session := Session{}
+			loaded, ok, err := s.Get(key)
+			if err != nil {
+				return fmt.Errorf("failed to load session: %w", err)
+			}
+			if ok {
+				if err := json.Unmarshal(loaded, &session); err != nil {
+					return fmt.Errorf("failed to decode session: %w", err)
+				}
+			}
+
+			ctx.Set(sessionContextKey, session)
+			err = next(b, ctx)
+
+			if len(session) == 0 {
+				if !ok {
+					return err
+				}
+				if dErr := s.Delete(key); dErr != nil && err == nil {
+					err = fmt.Errorf("failed to delete session: %w", dErr)
+				}
+				return err
+			}
+
+			data, mErr := json.Marshal(session)
+			if mErr != nil {
+				if err == nil {
+					err = fmt.Errorf("failed to encode session: %w", mErr)
+				}
+				return err
+			}
+			// Maps are encoded with sorted keys, so an unchanged session encodes to the same bytes.
+			if ok && bytes.Equal(data, loaded) {
+				return err
+			}
+			if sErr := s.Set(key, data, ttl); sErr != nil && err == nil {
+				err = fmt.Errorf("failed to save session: %w", sErr)
+			}
+
+			return err
+		}
+	}
+}
+
+// Session returns the session loaded by the Sessions middleware, or nil if it is not in use.
+func (c *Context) Session() Session {
+	v, ok := c.Get(sessionContextKey)
+	if !ok {
+		return nil
+	}
+	session, _ := v.(Session)
+	return session
+}
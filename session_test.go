@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KeralaBots/GoTGramBot/storage"
+)
+
+// countingStorage counts the writes made to the storage it wraps.
+type countingStorage struct {
+	storage.Storage
+	sets, deletes int
+}
+
+func (s *countingStorage) Set(key storage.Key, value []byte, ttl time.Duration) error {
+	s.sets++
+	return s.Storage.Set(key, value, ttl)
+}
+
+func (s *countingStorage) Delete(key storage.Key) error {
+	s.deletes++
+	return s.Storage.Delete(key)
+}
+
+func TestSessionsSaveOnlyChanges(t *testing.T) {
+	store := &countingStorage{Storage: storage.NewMemory()}
+	d := (&Bot{}).NewDispatcher()
+	d.Use(Sessions(store, 0))
+
+	var change func(s Session)
+	d.AddHandler(NewMessageHandler(func(b *Bot, ctx *Context) error {
+		change(ctx.Session())
+		return nil
+	}, nil))
+
+	steps := []struct {
+		name          string
+		change        func(s Session)
+		sets, deletes int
+	}{
+		{"empty session is not stored", func(s Session) {}, 0, 0},
+		{"new value is saved", func(s Session) { s["step"] = "name" }, 1, 0},
+		{"unchanged session is not saved", func(s Session) {}, 1, 0},
+		{"same value is not saved", func(s Session) { s["step"] = "name" }, 1, 0},
+		{"changed value is saved", func(s Session) { s["step"] = "age" }, 2, 0},
+		{"emptied session is deleted", func(s Session) { delete(s, "step") }, 2, 1},
+		{"missing session is not deleted again", func(s Session) {}, 2, 1},
+	}
+
+	for _, step := range steps {
+		change = step.change
+		d.handleUpdate(NewContext(context.Background(), textUpdate("hello")))
+		if store.sets != step.sets || store.deletes != step.deletes {
+			t.Errorf("%s: got %d sets and %d deletes, want %d and %d", step.name, store.sets, store.deletes, step.sets, step.deletes)
+		}
+	}
+}
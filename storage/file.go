@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File keeps values in memory and writes them to a JSON file on every change.
+type File struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// NewFile opens the storage at path, loading any values saved by a previous run.
+func NewFile(path string) (*File, error) {
+	f := &File{
+		path:    path,
+		entries: make(map[string]entry),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read storage file: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &f.entries); err != nil {
+			return nil, fmt.Errorf("failed to decode storage file: %w", err)
+		}
+	}
+
+	return f, nil
+}
+
+func (f *File) Get(key Key) ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e, ok := f.entries[key.String()]
+	if !ok || e.expired(time.Now()) {
+		return nil, false, nil
+	}
+	return append([]byte(nil), e.Value...), true, nil
+}
+
+func (f *File) Set(key Key, value []byte, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entries[key.String()] = newEntry(value, ttl)
+	return f.save()
+}
+
+func (f *File) Delete(key Key) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.entries[key.String()]; !ok {
+		return nil
+	}
+	delete(f.entries, key.String())
+	return f.save()
+}
+
+// save drops expired values and atomically replaces the file.
+func (f *File) save() error {
+	now := time.Now()
+	for k, e := range f.entries {
+		if e.expired(now) {
+			delete(f.entries, k)
+		}
+	}
+
+	data, err := json.Marshal(f.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode storage: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write storage file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write storage file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write storage file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to write storage file: %w", err)
+	}
+
+	return nil
+}
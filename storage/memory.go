@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"sync"
+	"time"
+)
+
+// How often Set drops the expired values of keys which are never read again.
+const sweepInterval = time.Minute
+
+// Memory keeps values in memory. They are lost on restart.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[Key]entry
+	swept   time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[Key]entry)}
+}
+
+func (m *Memory) Get(key Key) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if e.expired(time.Now()) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return append([]byte(nil), e.Value...), true, nil
+}
+
+func (m *Memory) Set(key Key, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now := time.Now(); now.Sub(m.swept) >= sweepInterval {
+		for k, e := range m.entries {
+			if e.expired(now) {
+				delete(m.entries, k)
+			}
+		}
+		m.swept = now
+	}
+
+	m.entries[key] = newEntry(value, ttl)
+	return nil
+}
+
+func (m *Memory) Delete(key Key) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
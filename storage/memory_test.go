@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemorySetSweepsExpired(t *testing.T) {
+	m := NewMemory()
+	if err := m.Set(Key{UserId: 1, Name: "conversation"}, []byte("a"), time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set(Key{UserId: 2, Name: "conversation"}, []byte("b"), 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+
+	// No sweep until sweepInterval has passed.
+	if err := m.Set(Key{UserId: 3, Name: "conversation"}, []byte("c"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.entries) != 3 {
+		t.Fatalf("got %d entries before the sweep, want 3", len(m.entries))
+	}
+
+	m.swept = time.Now().Add(-sweepInterval)
+	if err := m.Set(Key{UserId: 4, Name: "conversation"}, []byte("d"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.entries) != 3 {
+		t.Errorf("got %d entries after the sweep, want 3", len(m.entries))
+	}
+	if _, ok := m.entries[Key{UserId: 1, Name: "conversation"}]; ok {
+		t.Error("expired entry was not swept")
+	}
+}
+
+func TestMemoryGetExpired(t *testing.T) {
+	m := NewMemory()
+	key := Key{UserId: 1, Name: "session"}
+	if err := m.Set(key, []byte("a"), time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+
+	if _, ok, err := m.Get(key); ok || err != nil {
+		t.Errorf("Get of an expired value = %v, %v, want false, nil", ok, err)
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Key identifies a stored value. ChatId and UserId are zero for values not tied to a chat or user.
+type Key struct {
+	BotId  int64
+	ChatId int64
+	UserId int64
+	Name   string
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%d:%d:%d:%s", k.BotId, k.ChatId, k.UserId, k.Name)
+}
+
+// Storage is a key/value store used for sessions, conversation states and anything else that should
+// survive between updates.
+type Storage interface {
+	// Get returns the value stored for key, and false if there is none or it has expired.
+	Get(key Key) ([]byte, bool, error)
+	// Set stores value for key. A ttl of zero keeps it until it is deleted.
+	Set(key Key, value []byte, ttl time.Duration) error
+	Delete(key Key) error
+}
+
+// GetJSON decodes the value stored for key into v.
+func GetJSON(s Storage, key Key, v interface{}) (bool, error) {
+	data, ok, err := s.Get(key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// SetJSON stores v encoded as JSON.
+func SetJSON(s Storage, key Key, v interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	return s.Set(key, data, ttl)
+}
+
+type entry struct {
+	Value   []byte    `json:"value"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+func newEntry(value []byte, ttl time.Duration) entry {
+	e := entry{Value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.Expires = time.Now().Add(ttl)
+	}
+	return e
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.Expires.IsZero() && now.After(e.Expires)
+}
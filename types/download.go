@@ -0,0 +1,47 @@
+package types
+
+import (
+	"context"
+	"io"
+)
+
+// Downloader fetches the content of files by their file_id. It is implemented by *bot.Bot.
+// This file is written by hand; scripts/build.py does not generate it.
+type Downloader interface {
+	DownloadFileWithContext(ctx context.Context, fileId string) (io.ReadCloser, error)
+}
+
+// Download fetches the photo using d. The caller must close the returned reader.
+func (v PhotoSize) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
+
+// Download fetches the document using d. The caller must close the returned reader.
+func (v Document) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
+
+// Download fetches the voice note using d. The caller must close the returned reader.
+func (v Voice) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
+
+// Download fetches the video using d. The caller must close the returned reader.
+func (v Video) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
+
+// Download fetches the audio file using d. The caller must close the returned reader.
+func (v Audio) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
+
+// Download fetches the animation using d. The caller must close the returned reader.
+func (v Animation) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
+
+// Download fetches the video note using d. The caller must close the returned reader.
+func (v VideoNote) Download(ctx context.Context, d Downloader) (io.ReadCloser, error) {
+	return d.DownloadFileWithContext(ctx, v.FileId)
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// InputFile is a file to send: either a reference to a file Telegram can fetch on its own,
+// or content which is uploaded using multipart/form-data.
+// This type is written by hand; scripts/build.py does not generate it.
+type InputFile interface {
+	// Reference returns the file_id or URL to send instead of uploading, and false if the file has to be uploaded.
+	Reference() (string, bool)
+	// Open returns the name and content of the file to upload. It is called again when a request is retried.
+	Open() (string, io.ReadCloser, error)
+}
+
+// attach returns what to send in place of f inside a JSON parameter: its reference, or "attach://<name>"
+// after adding f to the files to upload under name. A nil f is sent as an empty string.
+func attach(f InputFile, name string, data map[string]InputFile) string {
+	if f == nil {
+		return ""
+	}
+	if ref, ok := f.Reference(); ok {
+		return ref
+	}
+	data[name] = f
+	return "attach://" + name
+}
+
+type fileReference string
+
+// FromFileID refers to a file which is already stored on the Telegram servers.
+func FromFileID(fileId string) InputFile {
+	return fileReference(fileId)
+}
+
+// FromURL lets Telegram download the file from url.
+func FromURL(url string) InputFile {
+	return fileReference(url)
+}
+
+func (f fileReference) Reference() (string, bool) {
+	return string(f), true
+}
+
+func (f fileReference) Open() (string, io.ReadCloser, error) {
+	return "", nil, fmt.Errorf("%s is a reference and cannot be uploaded", string(f))
+}
+
+type pathFile string
+
+// FromPath uploads the local file at path.
+func FromPath(path string) InputFile {
+	return pathFile(path)
+}
+
+func (f pathFile) Reference() (string, bool) {
+	return "", false
+}
+
+func (f pathFile) Open() (string, io.ReadCloser, error) {
+	file, err := os.Open(string(f))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return filepath.Base(string(f)), file, nil
+}
+
+type bytesFile struct {
+	name string
+	data []byte
+}
+
+// FromBytes uploads data under the given file name.
+func FromBytes(name string, data []byte) InputFile {
+	return &bytesFile{name: name, data: data}
+}
+
+func (f *bytesFile) Reference() (string, bool) {
+	return "", false
+}
+
+func (f *bytesFile) Open() (string, io.ReadCloser, error) {
+	return f.name, bytesReader{bytes.NewReader(f.data)}, nil
+}
+
+// bytesReader keeps the Len method of bytes.Reader visible, so the upload size is known in advance.
+type bytesReader struct {
+	*bytes.Reader
+}
+
+func (bytesReader) Close() error {
+	return nil
+}
+
+type readerFile struct {
+	name string
+	r    io.Reader
+	read bool
+}
+
+// FromReader uploads the content of r under the given file name. Unless r is an io.Seeker,
+// it can only be read once, so a failed upload cannot be retried.
+func FromReader(name string, r io.Reader) InputFile {
+	return &readerFile{name: name, r: r}
+}
+
+func (f *readerFile) Reference() (string, bool) {
+	return "", false
+}
+
+func (f *readerFile) Open() (string, io.ReadCloser, error) {
+	if f.read {
+		s, ok := f.r.(io.Seeker)
+		if !ok {
+			return "", nil, fmt.Errorf("reader for %s was already consumed", f.name)
+		}
+		if _, err := s.Seek(0, io.SeekStart); err != nil {
+			return "", nil, fmt.Errorf("failed to rewind %s: %w", f.name, err)
+		}
+	}
+	f.read = true
+	return f.name, io.NopCloser(f.r), nil
+}
@@ -2407,8 +2407,8 @@ type ResponseParameters struct {
 // - InputMediaVideo
 type InputMedia struct {
     Type string `json:"type"`
-    Media string `json:"media"`
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Media InputFile `json:"media"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     Caption string `json:"caption,omitempty"`
     ParseMode string `json:"parse_mode,omitempty"`
     CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
@@ -2423,13 +2423,28 @@ type InputMedia struct {
     SupportsStreaming bool `json:"supports_streaming,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputMedia) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputMedia
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
+}
+
 
 // Represents a photo to be sent.
 type InputMediaPhoto struct {
     // Type of the result, must be photo
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
     // Optional. Caption of the photo to be sent, 0-1024 characters after entities parsing
     Caption string `json:"caption,omitempty"`
     // Optional. Mode for parsing entities in the photo caption. See formatting options for more details.
@@ -2442,6 +2457,19 @@ type InputMediaPhoto struct {
     HasSpoiler bool `json:"has_spoiler,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputMediaPhoto) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputMediaPhoto
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+    })
+}
+
 func (v InputMediaPhoto) GetInputMedia() InputMediaPhoto {
     return v
 }
@@ -2451,9 +2479,9 @@ type InputMediaVideo struct {
     // Type of the result, must be video
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
     // Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass "attach://<file_attach_name>" if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     // Optional. Caption of the video to be sent, 0-1024 characters after entities parsing
     Caption string `json:"caption,omitempty"`
     // Optional. Mode for parsing entities in the video caption. See formatting options for more details.
@@ -2474,6 +2502,21 @@ type InputMediaVideo struct {
     HasSpoiler bool `json:"has_spoiler,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputMediaVideo) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputMediaVideo
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
+}
+
 func (v InputMediaVideo) GetInputMedia() InputMediaVideo {
     return v
 }
@@ -2483,9 +2526,9 @@ type InputMediaAnimation struct {
     // Type of the result, must be animation
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
     // Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass "attach://<file_attach_name>" if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     // Optional. Caption of the animation to be sent, 0-1024 characters after entities parsing
     Caption string `json:"caption,omitempty"`
     // Optional. Mode for parsing entities in the animation caption. See formatting options for more details.
@@ -2504,6 +2547,21 @@ type InputMediaAnimation struct {
     HasSpoiler bool `json:"has_spoiler,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputMediaAnimation) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputMediaAnimation
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
+}
+
 func (v InputMediaAnimation) GetInputMedia() InputMediaAnimation {
     return v
 }
@@ -2513,9 +2571,9 @@ type InputMediaAudio struct {
     // Type of the result, must be audio
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
     // Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass "attach://<file_attach_name>" if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     // Optional. Caption of the audio to be sent, 0-1024 characters after entities parsing
     Caption string `json:"caption,omitempty"`
     // Optional. Mode for parsing entities in the audio caption. See formatting options for more details.
@@ -2530,6 +2588,21 @@ type InputMediaAudio struct {
     Title string `json:"title,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputMediaAudio) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputMediaAudio
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
+}
+
 func (v InputMediaAudio) GetInputMedia() InputMediaAudio {
     return v
 }
@@ -2539,9 +2612,9 @@ type InputMediaDocument struct {
     // Type of the result, must be document
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
     // Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass "attach://<file_attach_name>" if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     // Optional. Caption of the document to be sent, 0-1024 characters after entities parsing
     Caption string `json:"caption,omitempty"`
     // Optional. Mode for parsing entities in the document caption. See formatting options for more details.
@@ -2552,13 +2625,23 @@ type InputMediaDocument struct {
     DisableContentTypeDetection bool `json:"disable_content_type_detection,omitempty"`
 }
 
-func (v InputMediaDocument) GetInputMedia() InputMediaDocument {
-    return v
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputMediaDocument) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputMediaDocument
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
 }
 
-// This object represents the contents of a file to be uploaded. Must be posted using multipart/form-data in the usual way that files are uploaded via the browser.
-type InputFile interface {
-
+func (v InputMediaDocument) GetInputMedia() InputMediaDocument {
+    return v
 }
 
 
@@ -2567,21 +2650,49 @@ type InputFile interface {
 // - InputPaidMediaVideo
 type InputPaidMedia struct {
     Type string `json:"type"`
-    Media string `json:"media"`
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Media InputFile `json:"media"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     Width int64 `json:"width,omitempty"`
     Height int64 `json:"height,omitempty"`
     Duration int64 `json:"duration,omitempty"`
     SupportsStreaming bool `json:"supports_streaming,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputPaidMedia) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputPaidMedia
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
+}
+
 
 // The paid media to send is a photo.
 type InputPaidMediaPhoto struct {
     // Type of the media, must be photo
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
+}
+
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputPaidMediaPhoto) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputPaidMediaPhoto
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+    })
 }
 
 func (v InputPaidMediaPhoto) GetInputPaidMedia() InputPaidMediaPhoto {
@@ -2593,9 +2704,9 @@ type InputPaidMediaVideo struct {
     // Type of the media, must be video
     Type string `json:"type"`
     // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Media string `json:"media"`
+    Media InputFile `json:"media"`
     // Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass "attach://<file_attach_name>" if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Thumbnail string `json:"thumbnail,omitempty"`
+    Thumbnail InputFile `json:"thumbnail,omitempty"`
     // Optional. Video width
     Width int64 `json:"width,omitempty"`
     // Optional. Video height
@@ -2606,6 +2717,21 @@ type InputPaidMediaVideo struct {
     SupportsStreaming bool `json:"supports_streaming,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputPaidMediaVideo) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputPaidMediaVideo
+    return json.Marshal(struct {
+        alias
+        Media string `json:"media"`
+        Thumbnail string `json:"thumbnail,omitempty"`
+    }{
+        alias: alias(v),
+        Media: attach(v.Media, prefix+"_media", data),
+        Thumbnail: attach(v.Thumbnail, prefix+"_thumbnail", data),
+    })
+}
+
 func (v InputPaidMediaVideo) GetInputPaidMedia() InputPaidMediaVideo {
     return v
 }
@@ -2676,7 +2802,7 @@ type MaskPosition struct {
 // This object describes a sticker to be added to a sticker set.
 type InputSticker struct {
     // The added sticker. Pass a file_id as a String to send a file that already exists on the Telegram servers, pass an HTTP URL as a String for Telegram to get a file from the Internet, upload a new one using multipart/form-data, or pass "attach://<file_attach_name>" to upload a new one using multipart/form-data under <file_attach_name> name. Animated and video stickers can't be uploaded via HTTP URL. More information on Sending Files: https://core.telegram.org/bots/api#sending-files
-    Sticker string `json:"sticker"`
+    Sticker InputFile `json:"sticker"`
     // Format of the added sticker, must be one of "static" for a .WEBP or .PNG image, "animated" for a .TGS animation, "video" for a WEBM video
     Format string `json:"format"`
     // List of 1-20 emoji associated with the sticker
@@ -2687,6 +2813,19 @@ type InputSticker struct {
     Keywords []string `json:"keywords,omitempty"`
 }
 
+// InputParams returns v as JSON. Its files to upload are added to data under names starting with prefix,
+// and referred to as "attach://<name>".
+func (v InputSticker) InputParams(prefix string, data map[string]InputFile) ([]byte, error) {
+    type alias InputSticker
+    return json.Marshal(struct {
+        alias
+        Sticker string `json:"sticker"`
+    }{
+        alias: alias(v),
+        Sticker: attach(v.Sticker, prefix+"_sticker", data),
+    })
+}
+
 
 // This object represents an incoming inline query. When the user sends an empty query, your bot could return some default or trending results.
 type InlineQuery struct {
@@ -1,68 +1,279 @@
 package bot
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"time"
 
 	"github.com/KeralaBots/GoTGramBot/types"
 )
 
+const (
+	minPollBackoff = time.Second
+	maxPollBackoff = time.Minute
+)
+
+// allUpdateTypes lists every update type, including chat_member, message_reaction and message_reaction_count
+// which Telegram only sends when asked for explicitly.
+var allUpdateTypes = []string{
+	"message", "edited_message", "channel_post", "edited_channel_post",
+	"business_connection", "business_message", "edited_business_message", "deleted_business_messages",
+	"message_reaction", "message_reaction_count", "inline_query", "chosen_inline_result", "callback_query",
+	"shipping_query", "pre_checkout_query", "poll", "poll_answer", "my_chat_member", "chat_member",
+	"chat_join_request", "chat_boost", "removed_chat_boost",
+}
+
+type updateTyper interface {
+	UpdateTypes() []string
+}
+
+// PollerStatus describes the health of the long polling loop.
+type PollerStatus struct {
+	Running             bool
+	LastSuccess         time.Time
+	ConsecutiveFailures int
+	LastError           error
+	// Why polling stopped for good, e.g. a revoked token or another instance polling with the same token.
+	StopReason error
+}
+
+// PollerStatus returns the current health of the long polling loop.
+func (d *Dispatcher) PollerStatus() PollerStatus {
+	d.pollMu.Lock()
+	defer d.pollMu.Unlock()
+
+	return d.pollStatus
+}
+
 func (d *Dispatcher) handleWorkers() error {
-	for d.IsRunning {
-		updates, err := d.Bot.GetUpdates(&GetUpdatesOpts{
-			Offset:  d.Offset,
-			Timeout: int64(TIMEOUT),
-		})
+	defer close(d.pollDone)
 
-		if err != nil {
-			return fmt.Errorf("failed to get update : %w", err)
+	d.setPollStatus(func(s *PollerStatus) {
+		s.Running = true
+		s.StopReason = nil
+	})
+	defer d.setPollStatus(func(s *PollerStatus) { s.Running = false })
+
+	d.resume()
+
+	allowedUpdates := d.allowedUpdates()
+	backoff := minPollBackoff
+	usernameLoaded := false
+	for d.ctx.Err() == nil {
+		var updates []types.Update
+		var err error
+		// The username is needed before the first update is handled; failures back off like polling does.
+		if !usernameLoaded {
+			err = d.loadUsername(d.ctx)
+			usernameLoaded = err == nil
+		}
+		if err == nil {
+			updates, err = d.Bot.GetUpdatesWithContext(d.ctx, &GetUpdatesOpts{
+				Offset:         d.Offset,
+				Timeout:        int64(TIMEOUT / time.Second),
+				AllowedUpdates: allowedUpdates,
+			})
 		}
 
-		if len(updates) > 0 {
-			//d.Offset = update[len(update)-1].UpdateId + 1
-			for _, update := range updates {
+		if err != nil {
+			if d.ctx.Err() != nil {
+				return nil
+			}
 
-				if update.Message != nil {
-					handleMessageWorkers(d.MessageHandlers, d.Bot, update.Message)
-				}
+			failures := 0
+			d.setPollStatus(func(s *PollerStatus) {
+				s.ConsecutiveFailures++
+				s.LastError = err
+				failures = s.ConsecutiveFailures
+			})
 
-				if update.ChannelPost != nil {
-					handleMessageWorkers(d.MessageHandlers, d.Bot, update.ChannelPost)
-				}
+			if isFatalPollError(err) {
+				err = fmt.Errorf("polling stopped: %w", err)
+				d.setPollStatus(func(s *PollerStatus) { s.StopReason = err })
+				log.Print(err)
+				d.cancel()
+				return err
+			}
 
-				if update.EditedMessage != nil {
-					handleMessageWorkers(d.EditedMessageHandlers, d.Bot, update.EditedMessage)
-				}
+			wait := backoff
+			var tgErr *TelegramError
+			if errors.As(err, &tgErr) && tgErr.RetryAfter() > 0 {
+				wait = tgErr.RetryAfter()
+			}
+			log.Printf("failed to get updates (attempt %d), retrying in %s: %v", failures, wait, err)
 
-				if update.EditedChannelPost != nil {
-					handleMessageWorkers(d.EditedMessageHandlers, d.Bot, update.EditedChannelPost)
-				}
+			if !sleepContext(d.ctx, wait) {
+				return nil
+			}
+			if backoff *= 2; backoff > maxPollBackoff {
+				backoff = maxPollBackoff
+			}
+			continue
+		}
 
-				if update.CallbackQuery != nil {
-					handleCallbackWorkers(d.CallbackHandlers, d.Bot, update.CallbackQuery)
-				}
+		backoff = minPollBackoff
+		// Everything below the offset we just sent has been acknowledged.
+		d.saveOffset()
+		d.setPollStatus(func(s *PollerStatus) {
+			s.LastSuccess = time.Now()
+			s.ConsecutiveFailures = 0
+			s.LastError = nil
+		})
 
-				d.Offset = update.UpdateId + 1
+		for i := range updates {
+			if !d.schedule(NewContext(d.context(), &updates[i])) {
+				// Stopped while waiting for room in the backlog, the update is fetched again on the next start.
+				break
 			}
+			d.Offset = updates[i].UpdateId + 1
 		}
 	}
 
 	return nil
 }
 
-func handleMessageWorkers(handlers []MessageHandlers, b *Bot, m *types.Message) {
-	for _, handler := range handlers {
-		check := handler.Filter.CheckMessage(m)
-		if check {
-			go handler.Function(b, m)
+// allowedUpdates returns AllowedUpdates, or the update types needed by the registered handlers.
+func (d *Dispatcher) allowedUpdates() []string {
+	if d.AllowedUpdates != nil {
+		return d.AllowedUpdates
+	}
+
+	var handlers []Handler
+	for _, group := range d.handlerGroups() {
+		handlers = append(handlers, group...)
+	}
+	allowed := handlerUpdateTypes(handlers)
+	if allowed == nil {
+		return allUpdateTypes
+	}
+	return allowed
+}
+
+// handlerUpdateTypes returns the update types needed by handlers, or nil if any of them does not tell.
+func handlerUpdateTypes(handlers []Handler) []string {
+	seen := map[string]bool{}
+	allowed := []string{}
+	for _, h := range handlers {
+		t, ok := h.(updateTyper)
+		if !ok || len(t.UpdateTypes()) == 0 {
+			return nil
+		}
+		for _, u := range t.UpdateTypes() {
+			if !seen[u] {
+				seen[u] = true
+				allowed = append(allowed, u)
+			}
 		}
 	}
+	return allowed
 }
 
-func handleCallbackWorkers(handlers []CallbackHandlers, b *Bot, m *types.CallbackQuery) {
-	for _, handler := range handlers {
-		check := handler.Filter.CheckCallback(m)
-		if check {
-			go handler.Function(b, m)
+func (d *Dispatcher) setPollStatus(fn func(s *PollerStatus)) {
+	d.pollMu.Lock()
+	defer d.pollMu.Unlock()
+
+	fn(&d.pollStatus)
+}
+
+// isFatalPollError reports whether retrying getUpdates is pointless: the token is invalid or revoked,
+// or another getUpdates request or a webhook is active for this bot.
+func isFatalPollError(err error) bool {
+	var tgErr *TelegramError
+	if !errors.As(err, &tgErr) {
+		return false
+	}
+
+	switch tgErr.Code {
+	case http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict:
+		return true
+	}
+	return false
+}
+
+// sleepContext waits for d, returning false if ctx was cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// context returns the context handlers run with, which is cancelled once the dispatcher has shut down.
+func (d *Dispatcher) context() context.Context {
+	if d.handlerCtx != nil {
+		return d.handlerCtx
+	}
+	return context.Background()
+}
+
+// ProcessUpdate routes a single update to the matching handlers in the background.
+// It blocks while MaxQueued updates are already waiting for a worker, and returns false if the
+// dispatcher stopped in the meantime, in which case the update is not handled.
+func (d *Dispatcher) ProcessUpdate(update *types.Update) bool {
+	return d.schedule(NewContext(d.context(), update))
+}
+
+// handleUpdate runs the first matching handler of every group, in group order.
+// A panic while checking the handlers, e.g. in a filter, stops handling the update and is reported as a *PanicError.
+func (d *Dispatcher) handleUpdate(ctx *Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.handleError(ctx, &PanicError{Value: r, Stack: debug.Stack()})
 		}
+	}()
+
+	for _, handlers := range d.handlerGroups() {
+		for _, h := range handlers {
+			match, ok := h.CheckUpdate(d.Bot, ctx)
+			if !ok {
+				continue
+			}
+
+			err := d.callHandler(h, ctx.withMatch(match))
+			if errors.Is(err, ContinueGroups) {
+				continue
+			}
+			if errors.Is(err, EndGroups) {
+				return
+			}
+			if err != nil {
+				d.handleError(ctx, err)
+			}
+			break
+		}
+	}
+}
+
+// callHandler runs h through the middlewares, turning panics into a *PanicError.
+func (d *Dispatcher) callHandler(h Handler, ctx *Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return d.wrap(h.HandleUpdate)(d.Bot, ctx)
+}
+
+func (d *Dispatcher) handleError(ctx *Context, err error) {
+	handler := d.ErrorHandler
+	if handler == nil {
+		handler = defaultErrorHandler
 	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("error handler panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+	handler(d.Bot, ctx, err)
 }
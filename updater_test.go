@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/KeralaBots/GoTGramBot/filters"
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+func textUpdate(text string) *types.Update {
+	return &types.Update{
+		UpdateId: 1,
+		Message: &types.Message{
+			MessageId: 1,
+			Chat:      &types.Chat{Id: 1, Type: "private"},
+			From:      &types.User{Id: 1, FirstName: "user"},
+			Text:      text,
+		},
+	}
+}
+
+// recorder builds handlers which log their name when they run and return the given error.
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) handler(name string, filter filters.Filter, err error) Handler {
+	return NewMessageHandler(func(b *Bot, ctx *Context) error {
+		r.calls = append(r.calls, name)
+		return err
+	}, filter)
+}
+
+func TestHandleUpdateGroups(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(d *Dispatcher, r *recorder)
+		want  []string
+	}{
+		{
+			name: "first match wins within a group",
+			setup: func(d *Dispatcher, r *recorder) {
+				d.AddHandler(r.handler("no match", filters.Regex("^bye$"), nil))
+				d.AddHandler(r.handler("first", filters.All, nil))
+				d.AddHandler(r.handler("second", filters.All, nil))
+			},
+			want: []string{"first"},
+		},
+		{
+			name: "groups run in ascending order",
+			setup: func(d *Dispatcher, r *recorder) {
+				d.AddHandlerToGroup(r.handler("group 1", filters.All, nil), 1)
+				d.AddHandlerToGroup(r.handler("group -1", filters.All, nil), -1)
+				d.AddHandler(r.handler("group 0", filters.All, nil))
+			},
+			want: []string{"group -1", "group 0", "group 1"},
+		},
+		{
+			name: "ContinueGroups tries the next handler of the group",
+			setup: func(d *Dispatcher, r *recorder) {
+				d.AddHandler(r.handler("first", filters.All, ContinueGroups))
+				d.AddHandler(r.handler("second", filters.All, nil))
+				d.AddHandlerToGroup(r.handler("group 1", filters.All, nil), 1)
+			},
+			want: []string{"first", "second", "group 1"},
+		},
+		{
+			name: "EndGroups stops later groups",
+			setup: func(d *Dispatcher, r *recorder) {
+				d.AddHandlerToGroup(r.handler("group -1", filters.All, EndGroups), -1)
+				d.AddHandler(r.handler("group 0", filters.All, nil))
+			},
+			want: []string{"group -1"},
+		},
+		{
+			name: "an error does not stop later groups",
+			setup: func(d *Dispatcher, r *recorder) {
+				d.AddHandler(r.handler("group 0", filters.All, errors.New("failed")))
+				d.AddHandlerToGroup(r.handler("group 1", filters.All, nil), 1)
+			},
+			want: []string{"group 0", "group 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := (&Bot{}).NewDispatcher()
+			d.ErrorHandler = func(b *Bot, ctx *Context, err error) {}
+			r := &recorder{}
+			tt.setup(d, r)
+
+			d.handleUpdate(NewContext(context.Background(), textUpdate("hello")))
+			if !reflect.DeepEqual(r.calls, tt.want) {
+				t.Errorf("handlers ran: %q, want %q", r.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateErrors(t *testing.T) {
+	d := (&Bot{}).NewDispatcher()
+	var errs []error
+	d.ErrorHandler = func(b *Bot, ctx *Context, err error) {
+		errs = append(errs, err)
+	}
+
+	failed := errors.New("failed")
+	r := &recorder{}
+	d.AddHandler(r.handler("failing", filters.All, failed))
+	d.AddHandlerToGroup(NewMessageHandler(func(b *Bot, ctx *Context) error {
+		panic("boom")
+	}, filters.All), 1)
+	d.AddHandlerToGroup(NewMessageHandler(func(b *Bot, ctx *Context) error {
+		return nil
+	}, filters.Func(func(m *types.Message) bool {
+		panic("filter boom")
+	})), 2)
+
+	d.handleUpdate(NewContext(context.Background(), textUpdate("hello")))
+
+	if len(errs) != 3 {
+		t.Fatalf("got %d errors, want 3: %v", len(errs), errs)
+	}
+	if errs[0] != failed {
+		t.Errorf("first error = %v, want the handler's error", errs[0])
+	}
+	for _, err := range errs[1:] {
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) {
+			t.Errorf("error = %v, want a *PanicError", err)
+		}
+	}
+}
+
+func TestHandleUpdateMiddlewareOrder(t *testing.T) {
+	d := (&Bot{}).NewDispatcher()
+	var calls []string
+	for _, name := range []string{"outer", "inner"} {
+		name := name
+		d.Use(func(next HandlerFunc) HandlerFunc {
+			return func(b *Bot, ctx *Context) error {
+				calls = append(calls, name)
+				return next(b, ctx)
+			}
+		})
+	}
+	d.AddHandler(NewMessageHandler(func(b *Bot, ctx *Context) error {
+		calls = append(calls, "handler")
+		return nil
+	}, filters.All))
+
+	d.handleUpdate(NewContext(context.Background(), textUpdate("hello")))
+
+	if want := []string{"outer", "inner", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
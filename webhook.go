@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/KeralaBots/GoTGramBot/types"
+)
+
+const (
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+	// Updates are a few kilobytes at most; anything bigger is not coming from Telegram.
+	maxUpdateSize = 1 << 20
+)
+
+type WebhookOpts struct {
+	// Address to listen on, e.g. ":8443".
+	ListenAddr string
+	// Path to serve updates on. Defaults to the path of the webhook URL.
+	Path string
+	// Checked against the X-Telegram-Bot-Api-Secret-Token header and passed to SetWebhook.
+	SecretToken string
+	// Serve over TLS using these files instead of plain HTTP.
+	CertFile string
+	KeyFile  string
+	// Upload CertFile to Telegram, needed for self-signed certificates.
+	UploadCertificate bool
+	// Extra options for SetWebhook. Certificate and SecretToken are filled from the fields above.
+	SetWebhookOpts *SetWebhookOpts
+}
+
+// WebhookHandler returns an http.Handler which feeds incoming updates to the dispatcher.
+// An empty secretToken disables the header check. When serving it without StartWebhook, create the bot
+// with ClientOpts.ValidateToken so command filters know the bot's username.
+func (d *Dispatcher) WebhookHandler(secretToken string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if secretToken != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(secretToken)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var update types.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&update); err != nil {
+			http.Error(w, "failed to decode update", http.StatusBadRequest)
+			return
+		}
+
+		if !d.ProcessUpdate(&update) {
+			// Telegram sends the update again later.
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// StartWebhook registers webhookUrl with Telegram and starts serving updates on opts.ListenAddr.
+func (d *Dispatcher) StartWebhook(webhookUrl string, opts WebhookOpts) error {
+	path := opts.Path
+	if path == "" {
+		u, err := url.Parse(webhookUrl)
+		if err != nil {
+			return fmt.Errorf("failed to parse webhook url: %w", err)
+		}
+		path = u.Path
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	if opts.UploadCertificate && opts.CertFile == "" {
+		return fmt.Errorf("UploadCertificate requires CertFile")
+	}
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return fmt.Errorf("CertFile and KeyFile must be set together")
+	}
+	if opts.CertFile != "" {
+		// Catch unreadable certificates before the webhook is registered with Telegram.
+		if _, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile); err != nil {
+			return fmt.Errorf("failed to load certificate: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", opts.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.ListenAddr, err)
+	}
+
+	if err := d.loadUsername(context.Background()); err != nil {
+		listener.Close()
+		return err
+	}
+
+	webhookOpts := SetWebhookOpts{}
+	if opts.SetWebhookOpts != nil {
+		webhookOpts = *opts.SetWebhookOpts
+	}
+	webhookOpts.SecretToken = opts.SecretToken
+	if webhookOpts.AllowedUpdates == nil {
+		webhookOpts.AllowedUpdates = d.allowedUpdates()
+	}
+	if d.DropPendingUpdates {
+		webhookOpts.DropPendingUpdates = true
+	}
+	if opts.UploadCertificate {
+		webhookOpts.Certificate = types.FromPath(opts.CertFile)
+	}
+
+	if _, err := d.Bot.SetWebhook(webhookUrl, &webhookOpts); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, d.WebhookHandler(opts.SecretToken))
+
+	d.begin(context.Background())
+	srv := &http.Server{Handler: mux}
+	d.server = srv
+
+	serveDone := make(chan error, 1)
+	d.serveDone = serveDone
+	go func() {
+		var err error
+		if opts.CertFile != "" {
+			err = srv.ServeTLS(listener, opts.CertFile, opts.KeyFile)
+		} else {
+			err = srv.Serve(listener)
+		}
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		if err != nil {
+			log.Printf("webhook server stopped: %v", err)
+			// Wake up Idle, Stop reports the error.
+			d.cancel()
+		}
+		serveDone <- err
+	}()
+
+	return nil
+}
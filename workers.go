@@ -0,0 +1,155 @@
+package bot
+
+// SequentialMode controls which updates the Dispatcher handles one after another.
+type SequentialMode int
+
+const (
+	// Every update is handled as soon as a worker is free.
+	SequentialNone SequentialMode = iota
+	// Updates from the same chat are handled one at a time, in the order they arrived.
+	SequentialPerChat
+	// Updates from the same user are handled one at a time, in the order they arrived.
+	SequentialPerUser
+)
+
+const defaultQueuedPerWorker = 100
+
+// schedule runs the handlers for ctx, respecting MaxConcurrency and Sequential. It returns false
+// if the dispatcher stopped while waiting for room in the backlog, in which case ctx is dropped.
+func (d *Dispatcher) schedule(ctx *Context) bool {
+	if !d.enqueue() {
+		return false
+	}
+	d.running.Add(1)
+
+	key, ok := d.sequentialKey(ctx)
+	if !ok {
+		go func() {
+			defer d.running.Done()
+			d.acquire()
+			defer d.release()
+			d.handleUpdate(ctx)
+		}()
+		return true
+	}
+
+	d.queueMu.Lock()
+	if d.queues == nil {
+		d.queues = make(map[int64][]*Context)
+	}
+	queue, active := d.queues[key]
+	d.queues[key] = append(queue, ctx)
+	d.queueMu.Unlock()
+
+	if !active {
+		go d.drain(key)
+	}
+	return true
+}
+
+// drain handles the queued updates of key one by one until none are left.
+func (d *Dispatcher) drain(key int64) {
+	for {
+		d.queueMu.Lock()
+		queue := d.queues[key]
+		if len(queue) == 0 {
+			delete(d.queues, key)
+			d.queueMu.Unlock()
+			return
+		}
+		ctx := queue[0]
+		queue[0] = nil
+		d.queues[key] = queue[1:]
+		d.queueMu.Unlock()
+
+		d.acquire()
+		d.handleUpdate(ctx)
+		d.release()
+		d.running.Done()
+	}
+}
+
+func (d *Dispatcher) sequentialKey(ctx *Context) (int64, bool) {
+	switch d.Sequential {
+	case SequentialPerChat:
+		if ctx.EffectiveChat != nil {
+			return ctx.EffectiveChat.Id, true
+		}
+		if ctx.EffectiveUser != nil {
+			return ctx.EffectiveUser.Id, true
+		}
+	case SequentialPerUser:
+		if ctx.EffectiveUser != nil {
+			return ctx.EffectiveUser.Id, true
+		}
+		if ctx.EffectiveChat != nil {
+			return ctx.EffectiveChat.Id, true
+		}
+	}
+	return 0, false
+}
+
+// pools returns the worker and backlog semaphores, or nils when MaxConcurrency is not set.
+func (d *Dispatcher) pools() (workers chan struct{}, backlog chan struct{}) {
+	if d.MaxConcurrency <= 0 {
+		return nil, nil
+	}
+
+	d.queueMu.Lock()
+	defer d.queueMu.Unlock()
+
+	if d.workers == nil {
+		queued := d.MaxQueued
+		if queued <= 0 {
+			queued = d.MaxConcurrency * defaultQueuedPerWorker
+		}
+		d.workers = make(chan struct{}, d.MaxConcurrency)
+		d.backlog = make(chan struct{}, queued)
+	}
+	return d.workers, d.backlog
+}
+
+// enqueue blocks until there is room in the backlog, returning false if the dispatcher stopped first.
+func (d *Dispatcher) enqueue() bool {
+	_, backlog := d.pools()
+	if backlog == nil {
+		return true
+	}
+
+	var done <-chan struct{}
+	if d.ctx != nil {
+		done = d.ctx.Done()
+	}
+
+	// Prefer room in the backlog over the stop signal, so updates are not dropped while there is space.
+	select {
+	case backlog <- struct{}{}:
+		return true
+	default:
+	}
+
+	select {
+	case backlog <- struct{}{}:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// acquire blocks until fewer than MaxConcurrency updates are being handled, then moves the update
+// from the backlog to a worker.
+func (d *Dispatcher) acquire() {
+	workers, backlog := d.pools()
+	if workers == nil {
+		return
+	}
+
+	workers <- struct{}{}
+	<-backlog
+}
+
+func (d *Dispatcher) release() {
+	if workers, _ := d.pools(); workers != nil {
+		<-workers
+	}
+}